@@ -1,24 +1,175 @@
 package main
 
 import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
 	"log"
+	"log/slog"
 	"net/http"
 	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
 
+	"markitos-it-app-website/internal/config"
+	"markitos-it-app-website/internal/domain/documents"
+	"markitos-it-app-website/internal/domain/search"
 	"markitos-it-app-website/internal/infrastructure/http/handlers"
+	"markitos-it-app-website/internal/infrastructure/markdown"
+	"markitos-it-app-website/internal/infrastructure/metrics"
+	"markitos-it-app-website/internal/templates"
+
+	"google.golang.org/grpc/connectivity"
 )
 
 func main() {
-	homeHandler, err := handlers.NewHomeHandler()
+	cfg, printOnly, err := config.Load(os.Args[1:])
+	if errors.Is(err, flag.ErrHelp) {
+		return
+	}
 	if err != nil {
-		log.Fatalf("Failed to create home handler: %v", err)
+		log.Fatalf("❌ Invalid configuration:\n%v", err)
+	}
+	if printOnly {
+		fmt.Print(cfg)
+		return
+	}
+
+	// El log estándar pasa por este handler con nivel info
+	slog.SetDefault(slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: cfg.Level()})))
+	slog.Info("⚙️  Effective configuration", "config", cfg)
+
+	if err := run(cfg); err != nil {
+		// Error level is always logged, whatever the configured level
+		slog.Error("❌ Exiting", "err", err)
+		os.Exit(1)
 	}
+}
+
+// run serves until SIGTERM or SIGINT and drains the server. Failures are
+// returned rather than exiting, so the deferred closes of the documents
+// service connection and the directory watcher always run.
+func run(cfg config.Config) error {
+	// SIGTERM llega de Kubernetes en cada rollout; SIGINT es Ctrl+C en local
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGTERM, syscall.SIGINT)
+	defer stop()
 
-	docsHandler, err := handlers.NewDocsHandler()
+	homeHandler, err := handlers.NewHomeHandler(cfg.SiteURL)
 	if err != nil {
-		log.Fatalf("Failed to create docs handler: %v", err)
+		return fmt.Errorf("failed to create home handler: %w", err)
 	}
 
+	healthHandler := handlers.NewHealthHandler(cfg.Health.DegradedReady)
+	appMetrics := metrics.New()
+
+	var docsSource documents.Repository
+	var dirRepo *documents.DirectoryRepository
+
+	if cfg.Docs.Backend == config.BackendDirectory {
+		// Modo autor: los documentos se leen del disco y se recargan al guardarlos
+		dirRepo, err = documents.NewDirectoryRepository(cfg.Docs.Dir)
+		if err != nil {
+			return fmt.Errorf("failed to load documents directory: %w", err)
+		}
+		defer dirRepo.Close()
+
+		docsSource = dirRepo
+	} else {
+		log.Printf("📡 Documents service address: %s", cfg.Docs.ServiceAddr)
+
+		grpcOpts := documents.DefaultGRPCOptions()
+		grpcOpts.CallTimeout = cfg.Docs.CallTimeout
+		grpcOpts.Interceptors = append(grpcOpts.Interceptors, appMetrics.UnaryClientInterceptor())
+		grpcRepo, err := documents.NewGRPCRepository(cfg.Docs.ServiceAddr, grpcOpts)
+		if err != nil {
+			return fmt.Errorf("failed to create documents service client: %w", err)
+		}
+		defer func() {
+			if err := grpcRepo.Close(); err != nil {
+				slog.Warn("Failed to close documents service connection", "err", err)
+				return
+			}
+			log.Printf("📡 Documents service connection closed")
+		}()
+
+		embeddedRepo, err := documents.NewEmbeddedRepository(templates.FS())
+		if err != nil {
+			return fmt.Errorf("failed to load embedded documents: %w", err)
+		}
+
+		docsSource = documents.NewFallbackRepository(grpcRepo, appMetrics.CountFallbacks(embeddedRepo))
+
+		healthHandler.AddCheck(handlers.HealthCheck{
+			Name:        "documents-service",
+			HasFallback: true,
+			Check: func(ctx context.Context) (string, error) {
+				state, breaker := grpcRepo.State(), grpcRepo.BreakerState()
+				detail := fmt.Sprintf("%s, connection %s, circuit breaker %s", cfg.Docs.ServiceAddr, state, breaker)
+				if breaker == documents.BreakerOpen {
+					return detail, documents.ErrCircuitOpen
+				}
+				// Idle es normal: la conexión se reabre en la siguiente llamada
+				if state != connectivity.Ready && state != connectivity.Idle {
+					return detail, fmt.Errorf("connection is %s", state)
+				}
+				return detail, nil
+			},
+		})
+	}
+
+	docsRepo := documents.NewCachedRepository(docsSource, cfg.Docs.CacheTTL, cfg.Docs.CacheMaxStale)
+	docsRepo.OnLookup(appMetrics.ObserveCache)
+
+	searcher := search.NewSearcher(docsRepo)
+	rebuildSearchIndex := func() {
+		if err := searcher.Rebuild(context.Background()); err != nil {
+			slog.Warn("Search index rebuild failed", "err", err)
+		}
+	}
+	go rebuildSearchIndex()
+	go searcher.Watch(ctx, cfg.Docs.CacheTTL)
+
+	if dirRepo != nil {
+		dirRepo.OnChange(docsRepo.Invalidate)
+		dirRepo.OnChange(rebuildSearchIndex)
+	}
+
+	markdownOpts := markdown.DefaultOptions()
+	markdownOpts.OnRender = appMetrics.ObserveRender
+	markdownOpts.Diagrams = markdown.NewDiagramRenderer(cfg.Markdown.DiagramCommands())
+	markdownOpts.TOCDepth = cfg.Markdown.TOCDepth
+	markdownRenderer := markdown.NewRenderer(markdownOpts)
+
+	docsHandler, err := handlers.NewDocsHandler(docsRepo, markdownRenderer, cfg.SiteURL)
+	if err != nil {
+		return fmt.Errorf("failed to create docs handler: %w", err)
+	}
+
+	feedHandler := handlers.NewFeedHandler(docsRepo, markdownRenderer, cfg.SiteURL)
+
+	sitemapHandler := handlers.NewSitemapHandler(docsRepo, cfg.SiteURL, cfg.Robots.Options())
+
+	apiHandler := handlers.NewAPIHandler(docsRepo, markdownRenderer, cfg.SiteURL)
+
+	searchHandler, err := handlers.NewSearchHandler(searcher, cfg.SiteURL)
+	if err != nil {
+		return fmt.Errorf("failed to create search handler: %w", err)
+	}
+
+	healthHandler.AddCheck(handlers.HealthCheck{
+		Name:    "documents-cache",
+		Startup: true,
+		Check: func(ctx context.Context) (string, error) {
+			if !docsRepo.Warm() {
+				return "", errors.New("documents not loaded yet")
+			}
+			return "warm", nil
+		},
+	})
+
 	mux := http.NewServeMux()
 
 	mux.HandleFunc("/", homeHandler.Index)
@@ -29,25 +180,70 @@ func main() {
 			docsHandler.View(w, r)
 		}
 	})
-	mux.HandleFunc("/health", func(w http.ResponseWriter, r *http.Request) {
-		w.WriteHeader(http.StatusOK)
-		w.Write([]byte("OK"))
-	})
+	mux.HandleFunc("/docs/search", searchHandler.Search)
+	mux.HandleFunc("/docs/feed.xml", feedHandler.RSS)
+	mux.HandleFunc("/docs/atom.xml", feedHandler.Atom)
+	mux.HandleFunc("/docs/feed.json", feedHandler.JSON)
+	mux.HandleFunc("/docs/category/{name}/feed.xml", feedHandler.CategoryRSS)
+	mux.HandleFunc("/sitemap.xml", sitemapHandler.Sitemap)
+	mux.HandleFunc("/sitemap/{page}", sitemapHandler.SitemapPage)
+	mux.HandleFunc("/robots.txt", sitemapHandler.Robots)
+	mux.HandleFunc("/api/", apiHandler.NotFound)
+	mux.HandleFunc("/api/v1/docs", apiHandler.Docs)
+	mux.HandleFunc("/api/v1/docs/{id}", apiHandler.Doc)
+	mux.HandleFunc("/api/v1/categories", apiHandler.Categories)
+	mux.HandleFunc("/api/v1/tags", apiHandler.Tags)
+	mux.HandleFunc("/api/v1/openapi.json", apiHandler.OpenAPI)
+	// /health se mantiene para los probes antiguos
+	mux.HandleFunc("/health", healthHandler.Ready)
+	mux.Handle("/metrics", appMetrics.Handler())
+	mux.HandleFunc("/livez", healthHandler.Live)
+	mux.HandleFunc("/readyz", healthHandler.Ready)
+	mux.HandleFunc("/startupz", healthHandler.Startup)
+
+	siteURL := strings.TrimRight(cfg.SiteURL, "/")
+	log.Printf("🚀 Server starting on http://%s", cfg.ListenAddr)
+	log.Printf("   Home: %s/", siteURL)
+	log.Printf("   Docs: %s/docs", siteURL)
+	log.Printf("   View Doc: %s/docs/{id}", siteURL)
+	log.Printf("   Search: %s/docs/search?q=", siteURL)
+	log.Printf("   API: %s/api/v1/openapi.json", siteURL)
+	log.Printf("   Feeds: %s/docs/feed.xml, /docs/atom.xml, /docs/feed.json", siteURL)
 
-	addr := "0.0.0.0:8080"
-	log.Printf("🚀 Server starting on http://%s", addr)
-	log.Printf("   Home: http://localhost:8080/")
-	log.Printf("   Docs: http://localhost:8080/docs")
-	log.Printf("   View Doc: http://localhost:8080/docs/{id}")
-	if err := http.ListenAndServe(addr, mux); err != nil {
-		log.Fatal(err)
+	srv := &http.Server{
+		Addr:              cfg.ListenAddr,
+		Handler:           appMetrics.Middleware(mux),
+		ReadHeaderTimeout: cfg.HTTP.ReadHeaderTimeout,
+		ReadTimeout:       cfg.HTTP.ReadTimeout,
+		WriteTimeout:      cfg.HTTP.WriteTimeout,
+		IdleTimeout:       cfg.HTTP.IdleTimeout,
+		MaxHeaderBytes:    cfg.HTTP.MaxHeaderBytes,
 	}
-}
 
-func getEnv(key, fallback string) string {
-	value := fallback
-	if v := os.Getenv(key); v != "" {
-		value = v
+	serveErr := make(chan error, 1)
+	go func() {
+		serveErr <- srv.ListenAndServe()
+	}()
+
+	select {
+	case err := <-serveErr:
+		return fmt.Errorf("server failed: %w", err)
+	case <-ctx.Done():
+	}
+	// A second signal kills the process without waiting for the drain
+	stop()
+
+	// Readiness falla primero para que el Service deje de enviar tráfico
+	log.Printf("🛑 Shutting down: failing readiness for %s, then draining connections for up to %s", cfg.HTTP.ShutdownDelay, cfg.HTTP.ShutdownGrace)
+	healthHandler.ShuttingDown()
+	time.Sleep(cfg.HTTP.ShutdownDelay)
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), cfg.HTTP.ShutdownGrace)
+	defer cancel()
+	if err := srv.Shutdown(shutdownCtx); err != nil {
+		slog.Warn("Requests still in flight, closing their connections", "grace", cfg.HTTP.ShutdownGrace, "err", err)
+		srv.Close()
 	}
-	return value
+	log.Printf("✅ Server stopped")
+	return nil
 }
@@ -0,0 +1,422 @@
+package config
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log/slog"
+	"net"
+	"net/url"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"markitos-it-app-website/internal/domain/documents"
+	"markitos-it-app-website/internal/infrastructure/markdown"
+	"markitos-it-app-website/internal/infrastructure/sitemap"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Backends the documents can be read from
+const (
+	// BackendGRPC reads from the documents service, falling back to the embedded docs
+	BackendGRPC = "grpc"
+	// BackendDirectory reads markdown files from disk and reloads them on change
+	BackendDirectory = "directory"
+)
+
+// Config is the whole configuration of the app. Each value comes from, in
+// increasing priority: the defaults, the YAML file given by -config or
+// CONFIG_FILE, environment variables and command-line flags.
+type Config struct {
+	ListenAddr string `yaml:"listen_addr"`
+	// SiteURL is the public base URL used for canonical links, feeds and sitemaps
+	SiteURL string `yaml:"site_url"`
+	// LogLevel is the lowest level logged: debug, info, warn or error
+	LogLevel string         `yaml:"log_level"`
+	HTTP     HTTPConfig     `yaml:"http"`
+	Health   HealthConfig   `yaml:"health"`
+	Docs     DocsConfig     `yaml:"docs"`
+	Markdown MarkdownConfig `yaml:"markdown"`
+	Robots   RobotsConfig   `yaml:"robots"`
+}
+
+type HTTPConfig struct {
+	ReadHeaderTimeout time.Duration `yaml:"read_header_timeout"`
+	ReadTimeout       time.Duration `yaml:"read_timeout"`
+	// WriteTimeout has to leave room for rendering diagrams on a cold cache
+	WriteTimeout   time.Duration `yaml:"write_timeout"`
+	IdleTimeout    time.Duration `yaml:"idle_timeout"`
+	MaxHeaderBytes int           `yaml:"max_header_bytes"`
+	// ShutdownDelay is how long the server keeps serving after a SIGTERM with
+	// readiness failing, so load balancers stop routing to it first
+	ShutdownDelay time.Duration `yaml:"shutdown_delay"`
+	// ShutdownGrace is how long in-flight requests get to finish afterwards
+	ShutdownGrace time.Duration `yaml:"shutdown_grace"`
+}
+
+type HealthConfig struct {
+	// DegradedReady keeps /readyz passing while the documents service is down
+	// and the embedded docs are served instead
+	DegradedReady bool `yaml:"degraded_ready"`
+}
+
+type DocsConfig struct {
+	// Backend is BackendGRPC or BackendDirectory; empty picks the directory
+	// backend when Dir is set
+	Backend     string        `yaml:"backend"`
+	ServiceAddr string        `yaml:"service_addr"`
+	Dir         string        `yaml:"dir"`
+	CallTimeout time.Duration `yaml:"call_timeout"`
+	CacheTTL    time.Duration `yaml:"cache_ttl"`
+	// CacheMaxStale is how long cached documents keep being served while the source is down
+	CacheMaxStale time.Duration `yaml:"cache_max_stale"`
+}
+
+type MarkdownConfig struct {
+	TOCDepth int `yaml:"toc_depth"`
+	// Diagram renderer commands, split on spaces; empty disables the diagram kind
+	MermaidRenderer  string `yaml:"mermaid_renderer"`
+	PlantUMLRenderer string `yaml:"plantuml_renderer"`
+}
+
+type RobotsConfig struct {
+	DisallowAll bool     `yaml:"disallow_all"`
+	Disallow    []string `yaml:"disallow"`
+}
+
+// Default returns the configuration used when nothing is set
+func Default() Config {
+	diagrams := markdown.DefaultDiagramCommands()
+	return Config{
+		ListenAddr: "0.0.0.0:8080",
+		SiteURL:    "http://localhost:8080",
+		LogLevel:   "info",
+		// Delay and grace fit in the 30s Kubernetes termination grace period
+		HTTP: HTTPConfig{
+			ReadHeaderTimeout: 5 * time.Second,
+			ReadTimeout:       15 * time.Second,
+			WriteTimeout:      30 * time.Second,
+			IdleTimeout:       2 * time.Minute,
+			MaxHeaderBytes:    1 << 20,
+			ShutdownDelay:     5 * time.Second,
+			ShutdownGrace:     20 * time.Second,
+		},
+		Docs: DocsConfig{
+			ServiceAddr:   "localhost:8888",
+			CallTimeout:   documents.DefaultGRPCOptions().CallTimeout,
+			CacheTTL:      time.Minute,
+			CacheMaxStale: 10 * time.Minute,
+		},
+		Markdown: MarkdownConfig{
+			TOCDepth:         markdown.DefaultOptions().TOCDepth,
+			MermaidRenderer:  strings.Join(diagrams["mermaid"], " "),
+			PlantUMLRenderer: strings.Join(diagrams["plantuml"], " "),
+		},
+		Robots: RobotsConfig{Disallow: sitemap.DefaultRobotsOptions().Disallow},
+	}
+}
+
+// Load builds the configuration from the command-line arguments (without the
+// program name) and the environment, and validates it. printOnly is set by
+// -print-config; flag.ErrHelp is returned when -h was given.
+func Load(args []string) (cfg Config, printOnly bool, err error) {
+	cfg = Default()
+
+	fs := flag.NewFlagSet("app", flag.ContinueOnError)
+	configFile := fs.String("config", os.Getenv("CONFIG_FILE"), "YAML configuration `file` (env CONFIG_FILE)")
+	printConfig := fs.Bool("print-config", false, "print the effective configuration and exit")
+	flags := cfg
+	fs.StringVar(&flags.ListenAddr, "listen", cfg.ListenAddr, "`address` to listen on (env LISTEN_ADDR)")
+	fs.StringVar(&flags.SiteURL, "site-url", cfg.SiteURL, "public base `URL` of the site (env SITE_URL)")
+	fs.StringVar(&flags.LogLevel, "log-level", cfg.LogLevel, "debug, info, warn or error (env LOG_LEVEL)")
+	fs.DurationVar(&flags.HTTP.ShutdownGrace, "shutdown-grace", cfg.HTTP.ShutdownGrace, "how long in-flight requests get to finish on shutdown (env HTTP_SHUTDOWN_GRACE)")
+	fs.BoolVar(&flags.Health.DegradedReady, "degraded-ready", cfg.Health.DegradedReady, "stay ready while serving fallback docs (env HEALTH_DEGRADED_READY)")
+	fs.StringVar(&flags.Docs.Backend, "docs-backend", cfg.Docs.Backend, "grpc or directory (env DOCS_BACKEND)")
+	fs.StringVar(&flags.Docs.ServiceAddr, "docs-service-addr", cfg.Docs.ServiceAddr, "documents service `address` (env DOCS_SERVICE_ADDR)")
+	fs.StringVar(&flags.Docs.Dir, "docs-dir", cfg.Docs.Dir, "markdown `directory` for the directory backend (env DOCS_DIR)")
+	fs.DurationVar(&flags.Docs.CallTimeout, "docs-timeout", cfg.Docs.CallTimeout, "timeout of a documents service call (env DOCS_CALL_TIMEOUT)")
+	fs.DurationVar(&flags.Docs.CacheTTL, "cache-ttl", cfg.Docs.CacheTTL, "documents cache TTL (env DOCS_CACHE_TTL)")
+	fs.DurationVar(&flags.Docs.CacheMaxStale, "cache-max-stale", cfg.Docs.CacheMaxStale, "how long stale documents are served while the source is down (env DOCS_CACHE_MAX_STALE)")
+	fs.IntVar(&flags.Markdown.TOCDepth, "toc-depth", cfg.Markdown.TOCDepth, "heading levels in the table of contents, 1-5 (env DOCS_TOC_DEPTH)")
+	if err := fs.Parse(args); err != nil {
+		return Config{}, false, err
+	}
+
+	if *configFile != "" {
+		if err := cfg.loadFile(*configFile); err != nil {
+			return Config{}, false, err
+		}
+	}
+
+	if err := cfg.loadEnv(os.Getenv); err != nil {
+		return Config{}, false, err
+	}
+
+	// Only the flags given on the command line override the file and the environment
+	fs.Visit(func(f *flag.Flag) {
+		switch f.Name {
+		case "listen":
+			cfg.ListenAddr = flags.ListenAddr
+		case "site-url":
+			cfg.SiteURL = flags.SiteURL
+		case "log-level":
+			cfg.LogLevel = flags.LogLevel
+		case "shutdown-grace":
+			cfg.HTTP.ShutdownGrace = flags.HTTP.ShutdownGrace
+		case "degraded-ready":
+			cfg.Health.DegradedReady = flags.Health.DegradedReady
+		case "docs-backend":
+			cfg.Docs.Backend = flags.Docs.Backend
+		case "docs-service-addr":
+			cfg.Docs.ServiceAddr = flags.Docs.ServiceAddr
+		case "docs-dir":
+			cfg.Docs.Dir = flags.Docs.Dir
+		case "docs-timeout":
+			cfg.Docs.CallTimeout = flags.Docs.CallTimeout
+		case "cache-ttl":
+			cfg.Docs.CacheTTL = flags.Docs.CacheTTL
+		case "cache-max-stale":
+			cfg.Docs.CacheMaxStale = flags.Docs.CacheMaxStale
+		case "toc-depth":
+			cfg.Markdown.TOCDepth = flags.Markdown.TOCDepth
+		}
+	})
+
+	if cfg.Docs.Backend == "" {
+		cfg.Docs.Backend = BackendGRPC
+		if cfg.Docs.Dir != "" {
+			cfg.Docs.Backend = BackendDirectory
+		}
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return Config{}, false, err
+	}
+	return cfg, *printConfig, nil
+}
+
+func (c *Config) loadFile(path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("config file: %w", err)
+	}
+	defer f.Close()
+
+	decoder := yaml.NewDecoder(f)
+	decoder.KnownFields(true)
+	if err := decoder.Decode(c); err != nil && !errors.Is(err, io.EOF) {
+		return fmt.Errorf("config file %s: %w", path, err)
+	}
+	return nil
+}
+
+// loadEnv applies the environment variables that are set and not empty
+func (c *Config) loadEnv(getenv func(string) string) error {
+	var errs []error
+
+	str := func(key string, dst *string) {
+		if v := getenv(key); v != "" {
+			*dst = v
+		}
+	}
+	duration := func(key string, dst *time.Duration) {
+		if v := getenv(key); v != "" {
+			d, err := time.ParseDuration(v)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("%s: %w", key, err))
+				return
+			}
+			*dst = d
+		}
+	}
+
+	str("LISTEN_ADDR", &c.ListenAddr)
+	str("SITE_URL", &c.SiteURL)
+	str("LOG_LEVEL", &c.LogLevel)
+	duration("HTTP_READ_HEADER_TIMEOUT", &c.HTTP.ReadHeaderTimeout)
+	duration("HTTP_READ_TIMEOUT", &c.HTTP.ReadTimeout)
+	duration("HTTP_WRITE_TIMEOUT", &c.HTTP.WriteTimeout)
+	duration("HTTP_IDLE_TIMEOUT", &c.HTTP.IdleTimeout)
+	duration("HTTP_SHUTDOWN_DELAY", &c.HTTP.ShutdownDelay)
+	duration("HTTP_SHUTDOWN_GRACE", &c.HTTP.ShutdownGrace)
+	str("DOCS_BACKEND", &c.Docs.Backend)
+	str("DOCS_SERVICE_ADDR", &c.Docs.ServiceAddr)
+	str("DOCS_DIR", &c.Docs.Dir)
+	duration("DOCS_CALL_TIMEOUT", &c.Docs.CallTimeout)
+	duration("DOCS_CACHE_TTL", &c.Docs.CacheTTL)
+	duration("DOCS_CACHE_MAX_STALE", &c.Docs.CacheMaxStale)
+	str("MERMAID_RENDERER", &c.Markdown.MermaidRenderer)
+	str("PLANTUML_RENDERER", &c.Markdown.PlantUMLRenderer)
+
+	if v := getenv("HTTP_MAX_HEADER_BYTES"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("HTTP_MAX_HEADER_BYTES: %w", err))
+		}
+		c.HTTP.MaxHeaderBytes = n
+	}
+	if v := getenv("DOCS_TOC_DEPTH"); v != "" {
+		depth, err := strconv.Atoi(v)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("DOCS_TOC_DEPTH: %w", err))
+		}
+		c.Markdown.TOCDepth = depth
+	}
+	if v := getenv("HEALTH_DEGRADED_READY"); v != "" {
+		degraded, err := strconv.ParseBool(v)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("HEALTH_DEGRADED_READY: %w", err))
+		}
+		c.Health.DegradedReady = degraded
+	}
+	if v := getenv("ROBOTS_DISALLOW_ALL"); v != "" {
+		all, err := strconv.ParseBool(v)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("ROBOTS_DISALLOW_ALL: %w", err))
+		}
+		c.Robots.DisallowAll = all
+	}
+	if v := getenv("ROBOTS_DISALLOW"); v != "" {
+		c.Robots.Disallow = nil
+		for _, path := range strings.Split(v, ",") {
+			if path = strings.TrimSpace(path); path != "" {
+				c.Robots.Disallow = append(c.Robots.Disallow, path)
+			}
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// Validate reports every invalid value at once
+func (c Config) Validate() error {
+	var errs []error
+
+	if _, _, err := net.SplitHostPort(c.ListenAddr); err != nil {
+		errs = append(errs, fmt.Errorf("listen_addr: %w", err))
+	}
+	if u, err := url.Parse(c.SiteURL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		errs = append(errs, fmt.Errorf("site_url: %q is not an absolute http(s) URL", c.SiteURL))
+	}
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(c.LogLevel)); err != nil {
+		errs = append(errs, fmt.Errorf("log_level: unknown level %q (want debug, info, warn or error)", c.LogLevel))
+	}
+
+	for _, timeout := range []struct {
+		name  string
+		value time.Duration
+	}{
+		{"http.read_header_timeout", c.HTTP.ReadHeaderTimeout},
+		{"http.read_timeout", c.HTTP.ReadTimeout},
+		{"http.write_timeout", c.HTTP.WriteTimeout},
+		{"http.idle_timeout", c.HTTP.IdleTimeout},
+		{"http.shutdown_grace", c.HTTP.ShutdownGrace},
+	} {
+		if timeout.value <= 0 {
+			errs = append(errs, fmt.Errorf("%s: must be positive", timeout.name))
+		}
+	}
+	if c.HTTP.ShutdownDelay < 0 {
+		errs = append(errs, errors.New("http.shutdown_delay: must not be negative"))
+	}
+	if c.HTTP.MaxHeaderBytes < 1 {
+		errs = append(errs, errors.New("http.max_header_bytes: must be positive"))
+	}
+
+	switch c.Docs.Backend {
+	case BackendGRPC:
+		if c.Docs.ServiceAddr == "" {
+			errs = append(errs, errors.New("docs.service_addr: required by the grpc backend"))
+		}
+	case BackendDirectory:
+		if c.Docs.Dir == "" {
+			errs = append(errs, errors.New("docs.dir: required by the directory backend"))
+		}
+	default:
+		errs = append(errs, fmt.Errorf("docs.backend: unknown backend %q (want %s or %s)", c.Docs.Backend, BackendGRPC, BackendDirectory))
+	}
+	if c.Docs.CallTimeout <= 0 {
+		errs = append(errs, errors.New("docs.call_timeout: must be positive"))
+	}
+	if c.Docs.CacheTTL <= 0 {
+		errs = append(errs, errors.New("docs.cache_ttl: must be positive"))
+	}
+	if c.Docs.CacheMaxStale < 0 {
+		errs = append(errs, errors.New("docs.cache_max_stale: must not be negative"))
+	}
+
+	if c.Markdown.TOCDepth < 1 || c.Markdown.TOCDepth > 5 {
+		errs = append(errs, errors.New("markdown.toc_depth: must be between 1 and 5"))
+	}
+
+	return errors.Join(errs...)
+}
+
+// String renders the configuration as YAML, in the format of the config file
+func (c Config) String() string {
+	out, err := yaml.Marshal(c)
+	if err != nil {
+		return "# " + err.Error() + "\n"
+	}
+	return string(out)
+}
+
+// LogValue logs the configuration as attributes named after the keys of the
+// config file, e.g. docs.cache_ttl=1m0s
+func (c Config) LogValue() slog.Value {
+	var tree map[string]any
+	out, err := yaml.Marshal(c)
+	if err == nil {
+		err = yaml.Unmarshal(out, &tree)
+	}
+	if err != nil {
+		return slog.StringValue(err.Error())
+	}
+	return slog.GroupValue(logAttrs(tree)...)
+}
+
+func logAttrs(tree map[string]any) []slog.Attr {
+	keys := make([]string, 0, len(tree))
+	for key := range tree {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	attrs := make([]slog.Attr, len(keys))
+	for i, key := range keys {
+		if sub, ok := tree[key].(map[string]any); ok {
+			attrs[i] = slog.Attr{Key: key, Value: slog.GroupValue(logAttrs(sub)...)}
+			continue
+		}
+		attrs[i] = slog.Any(key, tree[key])
+	}
+	return attrs
+}
+
+// Level returns LogLevel as a slog level; it is info when LogLevel is invalid
+func (c Config) Level() slog.Level {
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(c.LogLevel)); err != nil {
+		return slog.LevelInfo
+	}
+	return level
+}
+
+// DiagramCommands returns the diagram renderers to pass to markdown.NewDiagramRenderer
+func (c MarkdownConfig) DiagramCommands() markdown.DiagramCommands {
+	return markdown.DiagramCommands{
+		"mermaid":  strings.Fields(c.MermaidRenderer),
+		"plantuml": strings.Fields(c.PlantUMLRenderer),
+	}
+}
+
+// Options returns the robots.txt options of the sitemap handler
+func (c RobotsConfig) Options() sitemap.RobotsOptions {
+	return sitemap.RobotsOptions{DisallowAll: c.DisallowAll, Disallow: c.Disallow}
+}
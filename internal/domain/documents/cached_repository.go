@@ -0,0 +1,335 @@
+package documents
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+)
+
+const (
+	listCacheKey      = "list"
+	summariesCacheKey = "summaries"
+
+	// fetchTimeout bounds an upstream fetch once it is detached from the
+	// request that started it, so a hung source cannot pin a singleflight
+	// key and every caller waiting on it
+	fetchTimeout = 30 * time.Second
+)
+
+// CacheResult is how the cache answered a lookup
+type CacheResult string
+
+const (
+	CacheHit CacheResult = "hit"
+	// CacheStale is a hit served while the entry is refreshed in the background
+	CacheStale CacheResult = "stale"
+	CacheMiss  CacheResult = "miss"
+)
+
+type cacheEntry[T any] struct {
+	value     T
+	fetchedAt time.Time
+}
+
+// CachedRepository keeps the document list and individual documents in memory.
+// Entries younger than ttl are served directly; entries older than ttl but
+// younger than ttl+maxStale are served stale while a single background
+// refresh runs; anything older is fetched synchronously. Concurrent misses
+// for the same key share one upstream call.
+type CachedRepository struct {
+	source   Repository
+	ttl      time.Duration
+	maxStale time.Duration
+
+	mu        sync.RWMutex
+	list      *cacheEntry[[]Document]
+	summaries *cacheEntry[[]Document]
+	docs      map[string]*cacheEntry[*Document]
+
+	group    singleflight.Group
+	warm     atomic.Bool
+	onLookup func(kind string, result CacheResult)
+	// now is time.Now, replaced in tests to move the clock
+	now func() time.Time
+}
+
+// NewCachedRepository wraps source with an in-memory cache
+func NewCachedRepository(source Repository, ttl, maxStale time.Duration) *CachedRepository {
+	return &CachedRepository{
+		source:   source,
+		ttl:      ttl,
+		maxStale: maxStale,
+		docs:     make(map[string]*cacheEntry[*Document]),
+		now:      time.Now,
+	}
+}
+
+// OnLookup registers fn to be called on every lookup with the kind of entry
+// ("list", "summaries" or "document") and how it was answered. Call it
+// before serving.
+func (r *CachedRepository) OnLookup(fn func(kind string, result CacheResult)) {
+	r.onLookup = fn
+}
+
+func (r *CachedRepository) lookup(kind string, result CacheResult) {
+	if r.onLookup != nil {
+		r.onLookup(kind, result)
+	}
+}
+
+// List returns the cached document list, refreshing it when needed
+func (r *CachedRepository) List(ctx context.Context) ([]Document, error) {
+	return r.cachedList(ctx, listCacheKey, &r.list, r.fetchList)
+}
+
+// ListSummaries returns the cached summaries, refreshing them when needed.
+// Only the full listing is cached, so filters taken from request parameters
+// cannot grow the cache: a filtered listing is cut from it once it is loaded,
+// and until then goes to the source with the filter.
+func (r *CachedRepository) ListSummaries(ctx context.Context, filter Query) ([]Document, error) {
+	filter = filter.Filter()
+
+	r.mu.RLock()
+	loaded := r.summaries != nil
+	r.mu.RUnlock()
+
+	var docs []Document
+	var err error
+	if loaded || (filter.Category == "" && filter.Tag == "") {
+		docs, err = r.cachedList(ctx, summariesCacheKey, &r.summaries, r.fetchSummaries)
+		docs = filterDocuments(docs, filter)
+	} else {
+		r.lookup(summariesCacheKey, CacheMiss)
+		docs, err = r.source.ListSummaries(ctx, filter)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return r.withKnownStats(docs), nil
+}
+
+// withKnownStats fills in the stats of summaries that came without them, as
+// from a documents service that does not compute them, with those of the
+// same version of the document loaded in full since. Stats are best effort:
+// the other summaries keep zero stats, which the templates leave out. docs
+// is copied rather than modified, since it may be the cached slice.
+func (r *CachedRepository) withKnownStats(docs []Document) []Document {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var filled []Document
+	for i, doc := range docs {
+		if doc.Stats.Words > 0 {
+			continue
+		}
+		entry := r.docs[doc.ID]
+		if entry == nil || entry.value.UpdatedAt != doc.UpdatedAt || entry.value.Stats.Words == 0 {
+			continue
+		}
+		if filled == nil {
+			filled = slices.Clone(docs)
+		}
+		filled[i].Stats = entry.value.Stats
+	}
+
+	if filled == nil {
+		return docs
+	}
+	return filled
+}
+
+func (r *CachedRepository) cachedList(ctx context.Context, key string, slot **cacheEntry[[]Document], fetch func(context.Context) ([]Document, error)) ([]Document, error) {
+	r.mu.RLock()
+	entry := *slot
+	r.mu.RUnlock()
+
+	fetchAny := func(ctx context.Context) (any, error) {
+		return fetch(ctx)
+	}
+
+	if entry != nil {
+		switch r.freshness(entry.fetchedAt) {
+		case fresh:
+			r.lookup(key, CacheHit)
+			return entry.value, nil
+		case stale:
+			r.lookup(key, CacheStale)
+			r.refreshInBackground(ctx, key, fetchAny)
+			return entry.value, nil
+		}
+	}
+	r.lookup(key, CacheMiss)
+
+	v, err := r.fetchShared(ctx, key, fetchAny)
+	if err != nil {
+		return nil, err
+	}
+	return v.([]Document), nil
+}
+
+// Get returns a cached document, refreshing it when needed
+func (r *CachedRepository) Get(ctx context.Context, id string) (*Document, error) {
+	if err := ValidateID(id); err != nil {
+		return nil, err
+	}
+
+	r.mu.RLock()
+	entry := r.docs[id]
+	r.mu.RUnlock()
+
+	if entry != nil {
+		switch r.freshness(entry.fetchedAt) {
+		case fresh:
+			r.lookup("document", CacheHit)
+			return entry.value, nil
+		case stale:
+			r.lookup("document", CacheStale)
+			r.refreshInBackground(ctx, "doc:"+id, func(ctx context.Context) (any, error) {
+				return r.fetchDocument(ctx, id)
+			})
+			return entry.value, nil
+		}
+	}
+	r.lookup("document", CacheMiss)
+
+	v, err := r.fetchShared(ctx, "doc:"+id, func(ctx context.Context) (any, error) {
+		return r.fetchDocument(ctx, id)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return v.(*Document), nil
+}
+
+// Warm reports whether the document list has been loaded at least once,
+// so requests no longer wait on the source to render the index
+func (r *CachedRepository) Warm() bool {
+	return r.warm.Load()
+}
+
+// Invalidate drops every cached entry so the next call goes to the source
+func (r *CachedRepository) Invalidate() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.list = nil
+	r.summaries = nil
+	r.docs = make(map[string]*cacheEntry[*Document])
+}
+
+type cacheFreshness int
+
+const (
+	fresh cacheFreshness = iota
+	stale
+	expired
+)
+
+func (r *CachedRepository) freshness(fetchedAt time.Time) cacheFreshness {
+	age := r.now().Sub(fetchedAt)
+	switch {
+	case age < r.ttl:
+		return fresh
+	case age < r.ttl+r.maxStale:
+		return stale
+	default:
+		return expired
+	}
+}
+
+// fetchShared runs fetch once per key no matter how many callers miss at the
+// same time. The upstream call is detached from the caller's cancellation so
+// one impatient client cannot fail the call for everyone waiting on it.
+func (r *CachedRepository) fetchShared(ctx context.Context, key string, fetch func(context.Context) (any, error)) (any, error) {
+	ch := r.group.DoChan(key, func() (any, error) {
+		return fetchDetached(ctx, fetch)
+	})
+
+	select {
+	case res := <-ch:
+		return res.Val, res.Err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+func (r *CachedRepository) refreshInBackground(ctx context.Context, key string, fetch func(context.Context) (any, error)) {
+	ch := r.group.DoChan(key, func() (any, error) {
+		return fetchDetached(ctx, fetch)
+	})
+
+	go func() {
+		if res := <-ch; res.Err != nil {
+			slog.Warn("Background refresh failed, keeping stale copy", "key", key, "err", res.Err)
+		}
+	}()
+}
+
+// fetchDetached runs fetch with the values of ctx but not its cancellation,
+// under fetchTimeout instead
+func fetchDetached(ctx context.Context, fetch func(context.Context) (any, error)) (any, error) {
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), fetchTimeout)
+	defer cancel()
+	return fetch(ctx)
+}
+
+func (r *CachedRepository) fetchList(ctx context.Context) ([]Document, error) {
+	docs, err := r.source.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	now := r.now()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.list = &cacheEntry[[]Document]{value: docs, fetchedAt: now}
+	r.warm.Store(true)
+	for i := range docs {
+		doc := docs[i]
+		r.docs[doc.ID] = &cacheEntry[*Document]{value: &doc, fetchedAt: now}
+	}
+
+	return docs, nil
+}
+
+func (r *CachedRepository) fetchSummaries(ctx context.Context) ([]Document, error) {
+	docs, err := r.source.ListSummaries(ctx, Query{})
+	if err != nil {
+		return nil, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.summaries = &cacheEntry[[]Document]{value: docs, fetchedAt: r.now()}
+	r.warm.Store(true)
+
+	return docs, nil
+}
+
+func (r *CachedRepository) fetchDocument(ctx context.Context, id string) (*Document, error) {
+	doc, err := r.source.Get(ctx, id)
+	if errors.Is(err, ErrNotFound) {
+		r.mu.Lock()
+		delete(r.docs, id)
+		r.mu.Unlock()
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.docs[id] = &cacheEntry[*Document]{value: doc, fetchedAt: r.now()}
+
+	return doc, nil
+}
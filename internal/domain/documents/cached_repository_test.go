@@ -0,0 +1,318 @@
+package documents
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// countingSource serves a replaceable document set, counts the calls it gets
+// and, when release is set, holds every Get until release is closed
+type countingSource struct {
+	mu      sync.Mutex
+	docs    []Document
+	err     error
+	lists   int
+	gets    atomic.Int32
+	release chan struct{}
+	// getCtx is the context of the last Get
+	getCtx context.Context
+}
+
+func (s *countingSource) set(docs []Document, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.docs, s.err = docs, err
+}
+
+func (s *countingSource) listCalls() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.lists
+}
+
+func (s *countingSource) List(context.Context) ([]Document, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.lists++
+	return s.docs, s.err
+}
+
+func (s *countingSource) ListSummaries(ctx context.Context, filter Query) ([]Document, error) {
+	docs, err := s.List(ctx)
+	return summaries(filterDocuments(docs, filter)), err
+}
+
+func (s *countingSource) Get(ctx context.Context, id string) (*Document, error) {
+	s.gets.Add(1)
+	s.mu.Lock()
+	s.getCtx = ctx
+	stub := stubRepository{docs: s.docs, err: s.err}
+	s.mu.Unlock()
+
+	if s.release != nil {
+		<-s.release
+	}
+	return stub.Get(ctx, id)
+}
+
+// lookupCounter counts how the cache answered its lookups
+type lookupCounter struct {
+	mu     sync.Mutex
+	counts map[CacheResult]int
+}
+
+func (c *lookupCounter) record(_ string, result CacheResult) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.counts[result]++
+}
+
+func (c *lookupCounter) count(result CacheResult) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.counts[result]
+}
+
+// newTestCache caches source for a minute, serving stale for five more
+func newTestCache(source Repository, clock *fakeClock) (*CachedRepository, *lookupCounter) {
+	lookups := &lookupCounter{counts: map[CacheResult]int{}}
+	repo := NewCachedRepository(source, time.Minute, 5*time.Minute)
+	repo.now = clock.Now
+	repo.OnLookup(lookups.record)
+	return repo, lookups
+}
+
+func listIDs(t *testing.T, repo *CachedRepository) []string {
+	t.Helper()
+	docs, err := repo.List(context.Background())
+	if err != nil {
+		t.Fatalf("List error = %v", err)
+	}
+	ids := []string{}
+	for _, doc := range docs {
+		ids = append(ids, doc.ID)
+	}
+	return ids
+}
+
+func TestCachedRepositoryTTL(t *testing.T) {
+	clock := newFakeClock()
+	source := &countingSource{docs: []Document{{ID: "v1"}}}
+	repo, results := newTestCache(source, clock)
+
+	listIDs(t, repo)
+	source.set([]Document{{ID: "v2"}}, nil)
+	clock.Advance(time.Minute - time.Second)
+
+	if got := listIDs(t, repo); got[0] != "v1" || source.listCalls() != 1 {
+		t.Fatalf("List within the TTL = %v after %d source calls, want [v1] after 1", got, source.listCalls())
+	}
+	if results.count(CacheMiss) != 1 || results.count(CacheHit) != 1 {
+		t.Errorf("lookups = %v, want 1 miss and 1 hit", results.counts)
+	}
+}
+
+func TestCachedRepositoryServesStaleWhileRefreshing(t *testing.T) {
+	clock := newFakeClock()
+	source := &countingSource{docs: []Document{{ID: "v1"}}}
+	repo, results := newTestCache(source, clock)
+
+	listIDs(t, repo)
+	source.set([]Document{{ID: "v2"}}, nil)
+	clock.Advance(2 * time.Minute)
+
+	if got := listIDs(t, repo); got[0] != "v1" {
+		t.Fatalf("stale List = %v, want the cached [v1]", got)
+	}
+	if results.count(CacheStale) != 1 {
+		t.Errorf("lookups = %v, want 1 stale", results.counts)
+	}
+
+	// The refresh runs in the background; once it lands the entry is fresh
+	deadline := time.Now().Add(5 * time.Second)
+	for listIDs(t, repo)[0] != "v2" {
+		if time.Now().After(deadline) {
+			t.Fatal("background refresh never replaced the stale list")
+		}
+		time.Sleep(time.Millisecond)
+	}
+	if calls := source.listCalls(); calls != 2 {
+		t.Errorf("source called %d times, want 2: one load and one shared refresh", calls)
+	}
+}
+
+func TestCachedRepositoryStaleRefreshFailureKeepsCopy(t *testing.T) {
+	clock := newFakeClock()
+	source := &countingSource{docs: []Document{{ID: "v1"}}}
+	repo, _ := newTestCache(source, clock)
+
+	listIDs(t, repo)
+	source.set(nil, ErrUnavailable)
+	clock.Advance(2 * time.Minute)
+
+	for range 3 {
+		if got := listIDs(t, repo); got[0] != "v1" {
+			t.Fatalf("List with a failing source = %v, want the stale [v1]", got)
+		}
+	}
+}
+
+func TestCachedRepositoryMaxStale(t *testing.T) {
+	clock := newFakeClock()
+	source := &countingSource{docs: []Document{{ID: "v1"}}}
+	repo, results := newTestCache(source, clock)
+
+	listIDs(t, repo)
+	source.set([]Document{{ID: "v2"}}, nil)
+	clock.Advance(6 * time.Minute)
+
+	if got := listIDs(t, repo); got[0] != "v2" || source.listCalls() != 2 {
+		t.Fatalf("List past max stale = %v after %d source calls, want a synchronous fetch of [v2]", got, source.listCalls())
+	}
+	if results.count(CacheMiss) != 2 || results.count(CacheStale) != 0 {
+		t.Errorf("lookups = %v, want 2 misses and no stale hit", results.counts)
+	}
+
+	source.set(nil, ErrUnavailable)
+	clock.Advance(6 * time.Minute)
+	if _, err := repo.List(context.Background()); !errors.Is(err, ErrUnavailable) {
+		t.Errorf("List past max stale with a failing source = %v, want ErrUnavailable instead of the old copy", err)
+	}
+}
+
+func TestCachedRepositoryCollapsesConcurrentMisses(t *testing.T) {
+	source := &countingSource{docs: []Document{{ID: "doc"}}, release: make(chan struct{})}
+	repo, results := newTestCache(source, newFakeClock())
+
+	const callers = 10
+
+	var wg sync.WaitGroup
+	errs := make(chan error, callers)
+	for range callers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			doc, err := repo.Get(context.Background(), "doc")
+			if err == nil && doc.ID != "doc" {
+				err = fmt.Errorf("got document %q", doc.ID)
+			}
+			errs <- err
+		}()
+	}
+
+	// Every caller misses before the single upstream call is let through
+	for results.count(CacheMiss) < callers {
+		time.Sleep(time.Millisecond)
+	}
+	time.Sleep(10 * time.Millisecond)
+	close(source.release)
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		if err != nil {
+			t.Errorf("Get error = %v", err)
+		}
+	}
+	if gets := source.gets.Load(); gets != 1 {
+		t.Errorf("source Get called %d times for %d concurrent misses, want 1", gets, callers)
+	}
+}
+
+func TestCachedRepositoryDetachedFetch(t *testing.T) {
+	source := &countingSource{docs: []Document{{ID: "doc"}}, release: make(chan struct{})}
+	repo, _ := newTestCache(source, newFakeClock())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() {
+		_, err := repo.Get(ctx, "doc")
+		done <- err
+	}()
+
+	for source.gets.Load() == 0 {
+		time.Sleep(time.Millisecond)
+	}
+	cancel()
+	if err := <-done; !errors.Is(err, context.Canceled) {
+		t.Fatalf("Get after the caller gave up = %v, want context.Canceled", err)
+	}
+
+	source.mu.Lock()
+	fetchCtx := source.getCtx
+	source.mu.Unlock()
+	if fetchCtx.Err() != nil {
+		t.Errorf("upstream fetch cancelled with its caller: %v", fetchCtx.Err())
+	}
+	if deadline, ok := fetchCtx.Deadline(); !ok || time.Until(deadline) > fetchTimeout {
+		t.Errorf("upstream fetch deadline = %v, %v; want one within %s", deadline, ok, fetchTimeout)
+	}
+
+	// The fetch the caller abandoned still fills the cache
+	close(source.release)
+	if _, err := repo.Get(context.Background(), "doc"); err != nil {
+		t.Fatalf("Get error = %v", err)
+	}
+	if gets := source.gets.Load(); gets != 1 {
+		t.Errorf("source Get called %d times, want the abandoned fetch to be reused", gets)
+	}
+}
+
+// contentlessSummaries lists summaries without stats, like a documents
+// service that does not compute them, and counts the documents it serves
+type contentlessSummaries struct {
+	stubRepository
+	gets *int
+}
+
+func (r contentlessSummaries) ListSummaries(ctx context.Context, filter Query) ([]Document, error) {
+	docs, err := r.stubRepository.ListSummaries(ctx, filter)
+	for i := range docs {
+		docs[i].Stats = Stats{}
+	}
+	return docs, err
+}
+
+func (r contentlessSummaries) Get(ctx context.Context, id string) (*Document, error) {
+	*r.gets++
+	return r.stubRepository.Get(ctx, id)
+}
+
+func TestCachedRepositorySummaryStats(t *testing.T) {
+	content := base64.StdEncoding.EncodeToString([]byte("## Setup\n\nInstall the tool.\n"))
+	gets := 0
+	source := contentlessSummaries{stubRepository: stubRepository{docs: []Document{
+		withStats(Document{ID: "setup", UpdatedAt: "2025-01-02", ContentB64: content}),
+	}}, gets: &gets}
+	repo := NewCachedRepository(source, time.Minute, 0)
+	ctx := context.Background()
+
+	docs, err := repo.ListSummaries(ctx, Query{})
+	if err != nil {
+		t.Fatalf("ListSummaries error = %v", err)
+	}
+	if docs[0].Stats.Words != 0 || gets != 0 {
+		t.Fatalf("cold ListSummaries = %d words after %d Get calls, want 0 words without fetching documents", docs[0].Stats.Words, gets)
+	}
+
+	if _, err := repo.Get(ctx, "setup"); err != nil {
+		t.Fatalf("Get error = %v", err)
+	}
+
+	docs, err = repo.ListSummaries(ctx, Query{})
+	if err != nil {
+		t.Fatalf("ListSummaries error = %v", err)
+	}
+	if got := docs[0].Stats; got.Words != 4 || got.Sections != 1 {
+		t.Errorf("Stats = %d words, %d sections; want 4 words, 1 section", got.Words, got.Sections)
+	}
+	if docs[0].ContentB64 != "" {
+		t.Error("summary kept its content")
+	}
+}
@@ -0,0 +1,153 @@
+package documents
+
+import (
+	"errors"
+	"log"
+	"sync"
+	"time"
+)
+
+// ErrCircuitOpen is returned without calling upstream while the breaker is open
+var ErrCircuitOpen = errors.New("circuit breaker is open")
+
+// BreakerState is the state of a CircuitBreaker
+type BreakerState int
+
+const (
+	BreakerClosed BreakerState = iota
+	BreakerOpen
+	BreakerHalfOpen
+)
+
+func (s BreakerState) String() string {
+	switch s {
+	case BreakerClosed:
+		return "closed"
+	case BreakerOpen:
+		return "open"
+	case BreakerHalfOpen:
+		return "half-open"
+	default:
+		return "unknown"
+	}
+}
+
+// CircuitBreakerConfig holds the thresholds of a CircuitBreaker
+type CircuitBreakerConfig struct {
+	// FailureThreshold is the number of consecutive failures that opens the breaker
+	FailureThreshold int
+	// OpenTimeout is how long the breaker stays open before letting probes through
+	OpenTimeout time.Duration
+	// HalfOpenMaxCalls is the number of concurrent probes allowed while half-open
+	HalfOpenMaxCalls int
+}
+
+// CircuitBreaker fails calls fast after repeated upstream failures.
+// Closed lets every call through, Open rejects every call until OpenTimeout
+// has elapsed, and HalfOpen lets a few probes through: one success closes the
+// breaker again, one failure re-opens it.
+type CircuitBreaker struct {
+	name string
+	cfg  CircuitBreakerConfig
+	// now is time.Now, replaced in tests to move the clock
+	now func() time.Time
+
+	mu       sync.Mutex
+	state    BreakerState
+	failures int
+	probes   int
+	openedAt time.Time
+}
+
+// NewCircuitBreaker creates a closed breaker; name is only used in logs
+func NewCircuitBreaker(name string, cfg CircuitBreakerConfig) *CircuitBreaker {
+	if cfg.FailureThreshold <= 0 {
+		cfg.FailureThreshold = 1
+	}
+	if cfg.HalfOpenMaxCalls <= 0 {
+		cfg.HalfOpenMaxCalls = 1
+	}
+	return &CircuitBreaker{name: name, cfg: cfg, now: time.Now}
+}
+
+// State returns the current state of the breaker
+func (b *CircuitBreaker) State() BreakerState {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.state == BreakerOpen && b.now().Sub(b.openedAt) >= b.cfg.OpenTimeout {
+		return BreakerHalfOpen
+	}
+	return b.state
+}
+
+// Allow reports whether a call may proceed. Every allowed call must be
+// followed by exactly one call to Success, Failure or Release: while
+// half-open, Allow hands out probe slots that only those give back, so a
+// probe left unreported holds its slot for good and the breaker can stay
+// half-open, rejecting every call.
+func (b *CircuitBreaker) Allow() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case BreakerOpen:
+		if b.now().Sub(b.openedAt) < b.cfg.OpenTimeout {
+			return ErrCircuitOpen
+		}
+		b.setState(BreakerHalfOpen)
+		fallthrough
+	case BreakerHalfOpen:
+		if b.probes >= b.cfg.HalfOpenMaxCalls {
+			return ErrCircuitOpen
+		}
+		b.probes++
+	}
+	return nil
+}
+
+// Success records a successful call
+func (b *CircuitBreaker) Success() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.failures = 0
+	if b.state == BreakerHalfOpen {
+		b.setState(BreakerClosed)
+	}
+}
+
+// Failure records a failed call
+func (b *CircuitBreaker) Failure() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.failures++
+	if b.state == BreakerHalfOpen || b.failures >= b.cfg.FailureThreshold {
+		b.openedAt = b.now()
+		b.setState(BreakerOpen)
+	}
+}
+
+// Release gives back a half-open probe slot for a call whose outcome says
+// nothing about upstream health, such as a cancelled request
+func (b *CircuitBreaker) Release() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.state == BreakerHalfOpen && b.probes > 0 {
+		b.probes--
+	}
+}
+
+func (b *CircuitBreaker) setState(state BreakerState) {
+	if b.state == state {
+		return
+	}
+	log.Printf("🔌 Circuit breaker '%s': %s → %s", b.name, b.state, state)
+	b.state = state
+	b.probes = 0
+	if state == BreakerClosed {
+		b.failures = 0
+	}
+}
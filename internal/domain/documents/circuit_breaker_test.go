@@ -0,0 +1,210 @@
+package documents
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// fakeClock is a clock that only moves when told to
+type fakeClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func newFakeClock() *fakeClock {
+	return &fakeClock{now: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)}
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *fakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+}
+
+func newTestBreaker(clock *fakeClock) *CircuitBreaker {
+	b := NewCircuitBreaker("test", CircuitBreakerConfig{
+		FailureThreshold: 3,
+		OpenTimeout:      10 * time.Second,
+		HalfOpenMaxCalls: 1,
+	})
+	b.now = clock.Now
+	return b
+}
+
+// fail records n allowed calls that failed
+func fail(t *testing.T, b *CircuitBreaker, n int) {
+	t.Helper()
+	for range n {
+		if err := b.Allow(); err != nil {
+			t.Fatalf("Allow() = %v while %s", err, b.State())
+		}
+		b.Failure()
+	}
+}
+
+func TestCircuitBreakerOpensAfterThreshold(t *testing.T) {
+	clock := newFakeClock()
+	b := newTestBreaker(clock)
+
+	fail(t, b, 2)
+	if err := b.Allow(); err != nil {
+		t.Fatalf("Allow() = %v below the threshold", err)
+	}
+	b.Success()
+	fail(t, b, 2)
+	if got := b.State(); got != BreakerClosed {
+		t.Fatalf("a success resets the count: State() = %s, want closed", got)
+	}
+
+	fail(t, b, 1)
+	if got := b.State(); got != BreakerOpen {
+		t.Fatalf("State() = %s after 3 consecutive failures, want open", got)
+	}
+	if err := b.Allow(); !errors.Is(err, ErrCircuitOpen) {
+		t.Errorf("Allow() = %v while open, want ErrCircuitOpen", err)
+	}
+
+	clock.Advance(9 * time.Second)
+	if err := b.Allow(); !errors.Is(err, ErrCircuitOpen) {
+		t.Errorf("Allow() = %v before OpenTimeout, want ErrCircuitOpen", err)
+	}
+	clock.Advance(time.Second)
+	if got := b.State(); got != BreakerHalfOpen {
+		t.Errorf("State() = %s after OpenTimeout, want half-open", got)
+	}
+}
+
+func TestCircuitBreakerHalfOpenProbe(t *testing.T) {
+	tests := []struct {
+		name   string
+		report func(*CircuitBreaker)
+		want   BreakerState
+	}{
+		{name: "success closes", report: (*CircuitBreaker).Success, want: BreakerClosed},
+		{name: "failure re-opens", report: (*CircuitBreaker).Failure, want: BreakerOpen},
+		{name: "release frees the slot", report: (*CircuitBreaker).Release, want: BreakerHalfOpen},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clock := newFakeClock()
+			b := newTestBreaker(clock)
+			fail(t, b, 3)
+			clock.Advance(10 * time.Second)
+
+			if err := b.Allow(); err != nil {
+				t.Fatalf("probe Allow() = %v, want nil", err)
+			}
+			if err := b.Allow(); !errors.Is(err, ErrCircuitOpen) {
+				t.Fatalf("second probe Allow() = %v, want ErrCircuitOpen", err)
+			}
+
+			tt.report(b)
+			if got := b.State(); got != tt.want {
+				t.Fatalf("State() = %s, want %s", got, tt.want)
+			}
+
+			err := b.Allow()
+			switch tt.want {
+			case BreakerOpen:
+				if !errors.Is(err, ErrCircuitOpen) {
+					t.Errorf("Allow() = %v after a failed probe, want ErrCircuitOpen", err)
+				}
+				// The open timeout starts over from the failed probe
+				clock.Advance(10 * time.Second)
+				if got := b.State(); got != BreakerHalfOpen {
+					t.Errorf("State() = %s once re-opened timeout elapsed, want half-open", got)
+				}
+			default:
+				if err != nil {
+					t.Errorf("Allow() = %v, want nil", err)
+				}
+			}
+		})
+	}
+}
+
+func TestGRPCRepositoryInvoke(t *testing.T) {
+	tests := []struct {
+		name      string
+		err       error
+		wantCalls int
+		// wantOpen is whether three such calls open the breaker
+		wantOpen bool
+	}{
+		{name: "unavailable is retried", err: status.Error(codes.Unavailable, "down"), wantCalls: 3, wantOpen: true},
+		{name: "resource exhausted is retried", err: status.Error(codes.ResourceExhausted, "busy"), wantCalls: 3, wantOpen: true},
+		{name: "aborted is retried", err: status.Error(codes.Aborted, "conflict"), wantCalls: 3, wantOpen: true},
+		{name: "internal fails without retrying", err: status.Error(codes.Internal, "bug"), wantCalls: 1, wantOpen: true},
+		{name: "deadline exceeded fails without retrying", err: status.Error(codes.DeadlineExceeded, "slow"), wantCalls: 1, wantOpen: true},
+		{name: "unimplemented is released", err: status.Error(codes.Unimplemented, "old server"), wantCalls: 1},
+		{name: "not found is released", err: status.Error(codes.NotFound, "missing"), wantCalls: 1},
+		{name: "invalid argument is released", err: status.Error(codes.InvalidArgument, "bad id"), wantCalls: 1},
+		{name: "success", wantCalls: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newFakeGRPCRepository(nil)
+			r.opts.Retry = RetryPolicy{MaxAttempts: 3}
+			r.breaker = newTestBreaker(newFakeClock())
+
+			calls := 0
+			call := func(context.Context) error {
+				calls++
+				return tt.err
+			}
+
+			if err := r.invoke(context.Background(), call); !errors.Is(err, tt.err) {
+				t.Fatalf("invoke() = %v, want %v", err, tt.err)
+			}
+			if calls != tt.wantCalls {
+				t.Errorf("call ran %d times, want %d", calls, tt.wantCalls)
+			}
+
+			r.invoke(context.Background(), call)
+			r.invoke(context.Background(), call)
+			if got := r.breaker.State() == BreakerOpen; got != tt.wantOpen {
+				t.Errorf("breaker open after 3 calls = %v, want %v", got, tt.wantOpen)
+			}
+		})
+	}
+}
+
+func TestGRPCRepositoryInvokeReleasesHalfOpenProbe(t *testing.T) {
+	clock := newFakeClock()
+	r := newFakeGRPCRepository(nil)
+	r.breaker = newTestBreaker(clock)
+	fail(t, r.breaker, 3)
+	clock.Advance(10 * time.Second)
+
+	unimplemented := status.Error(codes.Unimplemented, "old server")
+	for range 3 {
+		err := r.invoke(context.Background(), func(context.Context) error { return unimplemented })
+		if !errors.Is(err, unimplemented) {
+			t.Fatalf("invoke() = %v, want the Unimplemented error, not a rejected probe", err)
+		}
+	}
+	if got := r.breaker.State(); got != BreakerHalfOpen {
+		t.Fatalf("State() = %s after Unimplemented probes, want half-open", got)
+	}
+
+	if err := r.invoke(context.Background(), func(context.Context) error { return nil }); err != nil {
+		t.Fatalf("invoke() = %v, want nil", err)
+	}
+	if got := r.breaker.State(); got != BreakerClosed {
+		t.Errorf("State() = %s after a successful probe, want closed", got)
+	}
+}
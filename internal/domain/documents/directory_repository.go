@@ -0,0 +1,181 @@
+package documents
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+const (
+	directoryDocsPattern = "*.md"
+
+	// reloadDebounce groups the bursts of events editors produce on save
+	// (truncate, write, chmod, rename...) into a single re-index
+	reloadDebounce = 200 * time.Millisecond
+)
+
+// DirectoryRepository reads markdown documents from a directory on disk and
+// re-indexes them whenever a file is created, modified or deleted, so authors
+// see their changes without rebuilding the binary
+type DirectoryRepository struct {
+	dir     string
+	watcher *fsnotify.Watcher
+
+	mu        sync.RWMutex
+	docs      []Document
+	listeners []func()
+
+	reload *time.Timer
+	done   chan struct{}
+}
+
+// NewDirectoryRepository loads every *.md file in dir and starts watching it.
+// Call Close to stop the watcher.
+func NewDirectoryRepository(dir string) (*DirectoryRepository, error) {
+	docs, err := loadDocuments(os.DirFS(dir), directoryDocsPattern)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load documents from %s: %w", dir, err)
+	}
+
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create file watcher: %w", err)
+	}
+	if err := watcher.Add(dir); err != nil {
+		watcher.Close()
+		return nil, fmt.Errorf("failed to watch %s: %w", dir, err)
+	}
+
+	r := &DirectoryRepository{
+		dir:     dir,
+		watcher: watcher,
+		docs:    docs,
+		done:    make(chan struct{}),
+	}
+	go r.watch()
+
+	log.Printf("📝 Loaded %d documents from %s, watching for changes", len(docs), dir)
+
+	return r, nil
+}
+
+// List retorna todos los documentos del directorio
+func (r *DirectoryRepository) List(ctx context.Context) ([]Document, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.docs, nil
+}
+
+// ListSummaries retorna los documentos del directorio del filtro sin su contenido
+func (r *DirectoryRepository) ListSummaries(ctx context.Context, filter Query) ([]Document, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return summaries(filterDocuments(r.docs, filter)), nil
+}
+
+// Get retorna un documento del directorio por su ID, o ErrNotFound si no existe
+func (r *DirectoryRepository) Get(ctx context.Context, id string) (*Document, error) {
+	if err := ValidateID(id); err != nil {
+		return nil, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for i := range r.docs {
+		if r.docs[i].ID == id {
+			doc := r.docs[i]
+			return &doc, nil
+		}
+	}
+
+	return nil, fmt.Errorf("%w: %q", ErrNotFound, id)
+}
+
+// OnChange registers fn to be called after every successful re-index
+func (r *DirectoryRepository) OnChange(fn func()) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.listeners = append(r.listeners, fn)
+}
+
+// Close stops watching the directory
+func (r *DirectoryRepository) Close() error {
+	err := r.watcher.Close()
+	<-r.done
+	return err
+}
+
+func (r *DirectoryRepository) watch() {
+	defer close(r.done)
+
+	for {
+		select {
+		case event, ok := <-r.watcher.Events:
+			if !ok {
+				r.stopReload()
+				return
+			}
+			if filepath.Ext(event.Name) != ".md" || event.Op == fsnotify.Chmod {
+				continue
+			}
+			r.scheduleReload()
+		case err, ok := <-r.watcher.Errors:
+			if !ok {
+				r.stopReload()
+				return
+			}
+			slog.Warn("Watcher error", "dir", r.dir, "err", err)
+		}
+	}
+}
+
+func (r *DirectoryRepository) scheduleReload() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.reload != nil {
+		r.reload.Stop()
+	}
+	r.reload = time.AfterFunc(reloadDebounce, r.reindex)
+}
+
+func (r *DirectoryRepository) stopReload() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.reload != nil {
+		r.reload.Stop()
+	}
+}
+
+// reindex reloads the whole directory. If any file is invalid the previous
+// index is kept, so a half-written document never takes the site down.
+func (r *DirectoryRepository) reindex() {
+	docs, err := loadDocuments(os.DirFS(r.dir), directoryDocsPattern)
+	if err != nil {
+		slog.Warn("Reload failed, keeping previous documents", "dir", r.dir, "err", err)
+		return
+	}
+
+	r.mu.Lock()
+	r.docs = docs
+	listeners := append([]func(){}, r.listeners...)
+	r.mu.Unlock()
+
+	log.Printf("🔄 Reloaded %d documents from %s", len(docs), r.dir)
+
+	for _, fn := range listeners {
+		fn()
+	}
+}
@@ -0,0 +1,54 @@
+package documents
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"log"
+)
+
+// embeddedDocsPattern matches the markdown documents bundled in the binary
+const embeddedDocsPattern = "docs/*.md"
+
+// EmbeddedRepository reads documents from the markdown files bundled in the binary
+type EmbeddedRepository struct {
+	docs []Document
+}
+
+// NewEmbeddedRepository parses every docs/*.md file in fsys, reporting any
+// invalid front matter with its file and field name
+func NewEmbeddedRepository(fsys fs.FS) (*EmbeddedRepository, error) {
+	docs, err := loadDocuments(fsys, embeddedDocsPattern)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load embedded documents: %w", err)
+	}
+
+	log.Printf("📚 Loaded %d embedded documents", len(docs))
+
+	return &EmbeddedRepository{docs: docs}, nil
+}
+
+// List retorna todos los documentos locales
+func (r *EmbeddedRepository) List(ctx context.Context) ([]Document, error) {
+	return r.docs, nil
+}
+
+// ListSummaries retorna los documentos locales del filtro sin su contenido
+func (r *EmbeddedRepository) ListSummaries(ctx context.Context, filter Query) ([]Document, error) {
+	return summaries(filterDocuments(r.docs, filter)), nil
+}
+
+// Get retorna un documento local por su ID, o ErrNotFound si no existe
+func (r *EmbeddedRepository) Get(ctx context.Context, id string) (*Document, error) {
+	if err := ValidateID(id); err != nil {
+		return nil, err
+	}
+
+	for i := range r.docs {
+		if r.docs[i].ID == id {
+			return &r.docs[i], nil
+		}
+	}
+
+	return nil, fmt.Errorf("%w: %q", ErrNotFound, id)
+}
@@ -0,0 +1,48 @@
+package documents
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	// ErrNotFound means the requested document does not exist
+	ErrNotFound = errors.New("document not found")
+	// ErrUnavailable means the document source could not be reached
+	ErrUnavailable = errors.New("documents source unavailable")
+	// ErrInvalidID means the requested ID can never match a document
+	ErrInvalidID = errors.New("invalid document id")
+)
+
+var validID = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]{0,127}$`)
+
+// ValidateID returns ErrInvalidID if id is not a well-formed document ID
+func ValidateID(id string) error {
+	if !validID.MatchString(id) {
+		return fmt.Errorf("%w: %q", ErrInvalidID, id)
+	}
+	return nil
+}
+
+// fromGRPCError maps a documents service error to the matching domain
+// error, keeping the original error in the chain
+func fromGRPCError(err error) error {
+	if errors.Is(err, ErrCircuitOpen) {
+		return fmt.Errorf("%w: %w", ErrUnavailable, err)
+	}
+
+	switch status.Code(err) {
+	case codes.NotFound:
+		return fmt.Errorf("%w: %w", ErrNotFound, err)
+	case codes.InvalidArgument:
+		return fmt.Errorf("%w: %w", ErrInvalidID, err)
+	case codes.Canceled:
+		return err
+	default:
+		return fmt.Errorf("%w: %w", ErrUnavailable, err)
+	}
+}
@@ -0,0 +1,229 @@
+package documents
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/url"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	yamlDelimiter = "---"
+	tomlDelimiter = "+++"
+	dateLayout    = "2006-01-02"
+)
+
+// frontMatter is the metadata block at the top of every markdown document,
+// either YAML between "---" lines or TOML between "+++" lines
+type frontMatter struct {
+	ID          string          `yaml:"id" toml:"id"`
+	Title       string          `yaml:"title" toml:"title"`
+	Description string          `yaml:"description" toml:"description"`
+	Category    string          `yaml:"category" toml:"category"`
+	Tags        []string        `yaml:"tags" toml:"tags"`
+	UpdatedAt   frontMatterDate `yaml:"updated_at" toml:"updated_at"`
+	CoverImage  string          `yaml:"cover_image" toml:"cover_image"`
+}
+
+// frontMatterDate accepts both bare and quoted dates in YAML and TOML
+type frontMatterDate struct {
+	time.Time
+}
+
+func (d *frontMatterDate) UnmarshalYAML(node *yaml.Node) error {
+	return d.parse(node.Value)
+}
+
+func (d *frontMatterDate) UnmarshalTOML(v any) error {
+	switch v := v.(type) {
+	case time.Time:
+		d.Time = v
+		return nil
+	case string:
+		return d.parse(v)
+	default:
+		return fmt.Errorf("expected a date, got %T", v)
+	}
+}
+
+func (d *frontMatterDate) parse(s string) error {
+	for _, layout := range []string{dateLayout, time.RFC3339} {
+		if t, err := time.Parse(layout, s); err == nil {
+			d.Time = t
+			return nil
+		}
+	}
+	return fmt.Errorf("expected a date like %s, got %q", dateLayout, s)
+}
+
+// FieldError describes an invalid front matter field in a document file
+type FieldError struct {
+	File  string
+	Field string
+	Msg   string
+}
+
+func (e *FieldError) Error() string {
+	if e.Field == "" {
+		return fmt.Sprintf("%s: %s", e.File, e.Msg)
+	}
+	return fmt.Sprintf("%s: field %q: %s", e.File, e.Field, e.Msg)
+}
+
+// loadDocuments parses every file in fsys matching pattern. All invalid
+// files are reported together so authors can fix them in one pass.
+func loadDocuments(fsys fs.FS, pattern string) ([]Document, error) {
+	paths, err := fs.Glob(fsys, pattern)
+	if err != nil {
+		return nil, err
+	}
+
+	docs := make([]Document, 0, len(paths))
+	seen := make(map[string]string, len(paths))
+	var errs []error
+
+	for _, p := range paths {
+		data, err := fs.ReadFile(fsys, p)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+
+		doc, err := parseDocument(p, data)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+
+		if other, ok := seen[doc.ID]; ok {
+			errs = append(errs, &FieldError{File: p, Field: "id", Msg: fmt.Sprintf("%q is already used by %s", doc.ID, other)})
+			continue
+		}
+		seen[doc.ID] = p
+
+		docs = append(docs, doc)
+	}
+
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+	return docs, nil
+}
+
+// parseDocument splits the front matter from the markdown body of the file
+// at filePath, validates it and builds the Document. The ID defaults to the
+// file name without extension.
+func parseDocument(filePath string, data []byte) (Document, error) {
+	meta, body, err := splitFrontMatter(filePath, data)
+	if err != nil {
+		return Document{}, err
+	}
+
+	if meta.ID == "" {
+		meta.ID = strings.TrimSuffix(path.Base(filePath), path.Ext(filePath))
+	}
+	if err := meta.validate(filePath); err != nil {
+		return Document{}, err
+	}
+
+	return withStats(Document{
+		ID:          meta.ID,
+		Title:       meta.Title,
+		Description: meta.Description,
+		Category:    meta.Category,
+		Tags:        meta.Tags,
+		UpdatedAt:   meta.UpdatedAt.Format(dateLayout),
+		ContentB64:  base64.StdEncoding.EncodeToString(body),
+		CoverImage:  meta.CoverImage,
+	}), nil
+}
+
+func splitFrontMatter(filePath string, data []byte) (frontMatter, []byte, error) {
+	var meta frontMatter
+
+	data = bytes.TrimPrefix(data, []byte("\xef\xbb\xbf"))
+	firstLine, rest, _ := bytes.Cut(data, []byte("\n"))
+	delimiter := string(bytes.TrimSpace(firstLine))
+	if delimiter != yamlDelimiter && delimiter != tomlDelimiter {
+		return meta, nil, &FieldError{File: filePath, Msg: "missing front matter, expected a leading --- (YAML) or +++ (TOML) line"}
+	}
+
+	var block []byte
+	found := false
+	for len(rest) > 0 {
+		var line []byte
+		line, rest, _ = bytes.Cut(rest, []byte("\n"))
+		if string(bytes.TrimSpace(line)) == delimiter {
+			found = true
+			break
+		}
+		block = append(block, line...)
+		block = append(block, '\n')
+	}
+	if !found {
+		return meta, nil, &FieldError{File: filePath, Msg: fmt.Sprintf("front matter is not closed by a %s line", delimiter)}
+	}
+
+	var err error
+	if delimiter == yamlDelimiter {
+		dec := yaml.NewDecoder(bytes.NewReader(block))
+		dec.KnownFields(true)
+		if err = dec.Decode(&meta); errors.Is(err, io.EOF) {
+			err = nil
+		}
+	} else {
+		var md toml.MetaData
+		md, err = toml.Decode(string(block), &meta)
+		if err == nil {
+			if undecoded := md.Undecoded(); len(undecoded) > 0 {
+				return meta, nil, &FieldError{File: filePath, Field: undecoded[0].String(), Msg: "unknown field"}
+			}
+		}
+	}
+	if err != nil {
+		return meta, nil, &FieldError{File: filePath, Msg: fmt.Sprintf("invalid front matter: %v", err)}
+	}
+
+	return meta, rest, nil
+}
+
+func (m frontMatter) validate(filePath string) error {
+	var errs []error
+	fieldErr := func(field, msg string) {
+		errs = append(errs, &FieldError{File: filePath, Field: field, Msg: msg})
+	}
+
+	if ValidateID(m.ID) != nil {
+		fieldErr("id", fmt.Sprintf("%q is not a valid document id", m.ID))
+	}
+	if strings.TrimSpace(m.Title) == "" {
+		fieldErr("title", "is required")
+	}
+	if strings.TrimSpace(m.Category) == "" {
+		fieldErr("category", "is required")
+	}
+	if m.UpdatedAt.IsZero() {
+		fieldErr("updated_at", "is required")
+	}
+	for i, tag := range m.Tags {
+		if strings.TrimSpace(tag) == "" {
+			fieldErr(fmt.Sprintf("tags[%d]", i), "must not be empty")
+		}
+	}
+	if m.CoverImage != "" {
+		if u, err := url.Parse(m.CoverImage); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			fieldErr("cover_image", "must be an absolute http(s) URL")
+		}
+	}
+
+	return errors.Join(errs...)
+}
@@ -4,95 +4,290 @@ import (
 	"context"
 	"fmt"
 	"log"
-	"os"
+	"log/slog"
+	"sync/atomic"
 	"time"
 
 	pb "markitos-it-app-website/proto"
 
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/connectivity"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/keepalive"
+	"google.golang.org/grpc/status"
 )
 
-var (
-	docsServiceAddr = os.Getenv("DOCS_SERVICE_ADDR")
+const (
+	// summariesPageSize is the page size requested from ListDocuments
+	summariesPageSize = 100
+	// maxSummaryPages guards against a server that never stops paginating
+	maxSummaryPages = 1000
+
+	// keepaliveTime stays at the gRPC server's default minimum ping interval
+	// so the documents service never answers our pings with GOAWAY
+	keepaliveTime    = 5 * time.Minute
+	keepaliveTimeout = 20 * time.Second
 )
 
-func init() {
-	if docsServiceAddr == "" {
-		docsServiceAddr = "localhost:8888"
+// GRPCOptions tunes how the GRPCRepository calls the documents service
+type GRPCOptions struct {
+	// CallTimeout bounds a whole call, retries included. A deadline on the
+	// caller's context applies too, but CachedRepository detaches its fetches
+	// from the request, so behind the cache only CallTimeout and the cache's
+	// own fetch timeout bound them.
+	CallTimeout time.Duration
+	Retry       RetryPolicy
+	Breaker     CircuitBreakerConfig
+	// Interceptors wrap every call attempt, e.g. to record metrics
+	Interceptors []grpc.UnaryClientInterceptor
+}
+
+// DefaultGRPCOptions returns options that fail over to the local fallback
+// within a few seconds when the documents service is down
+func DefaultGRPCOptions() GRPCOptions {
+	return GRPCOptions{
+		CallTimeout: 3 * time.Second,
+		Retry: RetryPolicy{
+			MaxAttempts:    3,
+			InitialBackoff: 50 * time.Millisecond,
+			MaxBackoff:     500 * time.Millisecond,
+		},
+		Breaker: CircuitBreakerConfig{
+			FailureThreshold: 5,
+			OpenTimeout:      30 * time.Second,
+			HalfOpenMaxCalls: 1,
+		},
 	}
-	log.Printf("📡 Documents service address: %s", docsServiceAddr)
 }
 
-// GetAllDocumentsFromService fetches all documents from the gRPC service
-func GetAllDocumentsFromService() ([]Document, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-	defer cancel()
+// GRPCRepository reads documents from the documents gRPC service over a
+// single long-lived connection shared by every request
+type GRPCRepository struct {
+	conn    *grpc.ClientConn
+	client  pb.DocumentServiceClient
+	stop    context.CancelFunc
+	opts    GRPCOptions
+	breaker *CircuitBreaker
 
-	conn, err := grpc.DialContext(
-		ctx,
-		docsServiceAddr,
+	// legacyServer is set once the service answers ListDocuments with Unimplemented
+	legacyServer atomic.Bool
+}
+
+// NewGRPCRepository opens the shared connection to the documents service at addr.
+// The connection is established in the background and re-established
+// automatically by gRPC; call Close on shutdown to release it.
+func NewGRPCRepository(addr string, opts GRPCOptions) (*GRPCRepository, error) {
+	conn, err := grpc.NewClient(
+		addr,
 		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithKeepaliveParams(keepalive.ClientParameters{
+			Time:    keepaliveTime,
+			Timeout: keepaliveTimeout,
+		}),
+		grpc.WithChainUnaryInterceptor(opts.Interceptors...),
 	)
 	if err != nil {
-		return nil, fmt.Errorf("failed to connect to documents service: %w", err)
+		return nil, fmt.Errorf("failed to create documents service client: %w", err)
+	}
+
+	ctx, stop := context.WithCancel(context.Background())
+	r := &GRPCRepository{
+		conn:    conn,
+		client:  pb.NewDocumentServiceClient(conn),
+		stop:    stop,
+		opts:    opts,
+		breaker: NewCircuitBreaker("documents-service", opts.Breaker),
+	}
+
+	conn.Connect()
+	go r.watchState(ctx)
+
+	return r, nil
+}
+
+// State returns the current connectivity state of the shared connection
+func (r *GRPCRepository) State() connectivity.State {
+	return r.conn.GetState()
+}
+
+// BreakerState returns the current state of the circuit breaker
+func (r *GRPCRepository) BreakerState() BreakerState {
+	return r.breaker.State()
+}
+
+// Close stops the state monitor and closes the shared connection
+func (r *GRPCRepository) Close() error {
+	r.stop()
+	return r.conn.Close()
+}
+
+// watchState logs every connectivity state transition until ctx is cancelled
+func (r *GRPCRepository) watchState(ctx context.Context) {
+	state := r.conn.GetState()
+	log.Printf("📡 Documents service connection: %s", state)
+
+	for r.conn.WaitForStateChange(ctx, state) {
+		state = r.conn.GetState()
+		switch state {
+		case connectivity.Ready:
+			log.Printf("✅ Documents service connection: %s", state)
+		case connectivity.TransientFailure:
+			slog.Warn("Documents service connection", "state", state)
+		default:
+			log.Printf("📡 Documents service connection: %s", state)
+		}
+	}
+}
+
+// invoke runs call through the circuit breaker and the retry policy, under a
+// deadline derived from ctx
+func (r *GRPCRepository) invoke(ctx context.Context, call func(context.Context) error) error {
+	if err := r.breaker.Allow(); err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, r.opts.CallTimeout)
+	defer cancel()
+
+	err := r.opts.Retry.do(ctx, call)
+	switch {
+	case err == nil:
+		r.breaker.Success()
+	case isUpstreamFailure(err):
+		r.breaker.Failure()
+	default:
+		r.breaker.Release()
 	}
-	defer conn.Close()
+	return err
+}
 
-	client := pb.NewDocumentServiceClient(conn)
-	resp, err := client.GetAllDocuments(ctx, &pb.GetAllDocumentsRequest{})
+// List fetches all documents from the gRPC service
+func (r *GRPCRepository) List(ctx context.Context) ([]Document, error) {
+	var resp *pb.GetAllDocumentsResponse
+	err := r.invoke(ctx, func(ctx context.Context) error {
+		var err error
+		resp, err = r.client.GetAllDocuments(ctx, &pb.GetAllDocumentsRequest{})
+		return err
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to fetch documents: %w", err)
+		return nil, fmt.Errorf("failed to fetch documents: %w", fromGRPCError(err))
 	}
 
 	docs := make([]Document, len(resp.Documents))
 	for i, pbDoc := range resp.Documents {
-		docs[i] = Document{
-			ID:          pbDoc.Id,
-			Title:       pbDoc.Title,
-			Description: pbDoc.Description,
-			Category:    pbDoc.Category,
-			Tags:        pbDoc.Tags,
-			UpdatedAt:   pbDoc.UpdatedAt.AsTime().Format("2006-01-02"),
-			ContentB64:  pbDoc.ContentB64,
-			CoverImage:  pbDoc.CoverImage,
-		}
+		docs[i] = documentFromProto(pbDoc)
 	}
 
 	return docs, nil
 }
 
-// GetDocumentByIdFromService fetches a single document by ID from the gRPC service
-func GetDocumentByIdFromService(id string) (*Document, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-	defer cancel()
+// ListSummaries fetches the documents of filter without their content,
+// following the ListDocuments page tokens. The filter is sent to the service
+// and applied again to the response, for services that ignore it. Against a
+// documents service that predates ListDocuments it falls back to
+// GetAllDocuments and drops the content.
+func (r *GRPCRepository) ListSummaries(ctx context.Context, filter Query) ([]Document, error) {
+	filter = filter.Filter()
+	if r.legacyServer.Load() {
+		return r.listSummariesLegacy(ctx, filter)
+	}
 
-	conn, err := grpc.DialContext(
-		ctx,
-		docsServiceAddr,
-		grpc.WithTransportCredentials(insecure.NewCredentials()),
-	)
-	if err != nil {
-		return nil, fmt.Errorf("failed to connect to documents service: %w", err)
+	var docs []Document
+	req := &pb.ListDocumentsRequest{
+		PageSize: summariesPageSize,
+		Category: filter.Category,
+		OrderBy:  pb.DocumentOrder_DOCUMENT_ORDER_UPDATED_DESC,
+		View:     pb.DocumentView_DOCUMENT_VIEW_SUMMARY,
+	}
+	if filter.Tag != "" {
+		req.Tags = []string{filter.Tag}
+	}
+
+	for range maxSummaryPages {
+		var resp *pb.ListDocumentsResponse
+		err := r.invoke(ctx, func(ctx context.Context) error {
+			var err error
+			resp, err = r.client.ListDocuments(ctx, req)
+			return err
+		})
+		if status.Code(err) == codes.Unimplemented {
+			slog.Warn("Documents service does not implement ListDocuments, using GetAllDocuments")
+			r.legacyServer.Store(true)
+			return r.listSummariesLegacy(ctx, filter)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to list documents: %w", fromGRPCError(err))
+		}
+
+		for _, pbDoc := range resp.Documents {
+			doc := documentFromProto(pbDoc)
+			doc.ContentB64 = ""
+			docs = append(docs, doc)
+		}
+
+		if resp.NextPageToken == "" {
+			return filterDocuments(docs, filter), nil
+		}
+		req.PageToken = resp.NextPageToken
 	}
-	defer conn.Close()
 
-	client := pb.NewDocumentServiceClient(conn)
-	resp, err := client.GetDocumentById(ctx, &pb.GetDocumentByIdRequest{Id: id})
+	return nil, fmt.Errorf("%w: ListDocuments returned more than %d pages", ErrUnavailable, maxSummaryPages)
+}
+
+func (r *GRPCRepository) listSummariesLegacy(ctx context.Context, filter Query) ([]Document, error) {
+	docs, err := r.List(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("failed to fetch document: %w", err)
+		return nil, err
+	}
+	return summaries(filterDocuments(docs, filter)), nil
+}
+
+// Get fetches a single document by ID from the gRPC service
+func (r *GRPCRepository) Get(ctx context.Context, id string) (*Document, error) {
+	if err := ValidateID(id); err != nil {
+		return nil, err
 	}
 
-	doc := &Document{
-		ID:          resp.Document.Id,
-		Title:       resp.Document.Title,
-		Description: resp.Document.Description,
-		Category:    resp.Document.Category,
-		Tags:        resp.Document.Tags,
-		UpdatedAt:   resp.Document.UpdatedAt.AsTime().Format("2006-01-02"),
-		ContentB64:  resp.Document.ContentB64,
-		CoverImage:  resp.Document.CoverImage,
+	var resp *pb.GetDocumentByIdResponse
+	err := r.invoke(ctx, func(ctx context.Context) error {
+		var err error
+		resp, err = r.client.GetDocumentById(ctx, &pb.GetDocumentByIdRequest{Id: id})
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch document: %w", fromGRPCError(err))
+	}
+	if resp.Document == nil {
+		return nil, fmt.Errorf("%w: %q", ErrNotFound, id)
 	}
 
-	return doc, nil
+	doc := documentFromProto(resp.Document)
+
+	return &doc, nil
+}
+
+// documentFromProto converts a proto document. Stats are computed from the
+// content when the response includes it, and otherwise taken from the stats
+// the service sends with summaries.
+func documentFromProto(pbDoc *pb.Document) Document {
+	doc := withStats(Document{
+		ID:          pbDoc.Id,
+		Title:       pbDoc.Title,
+		Description: pbDoc.Description,
+		Category:    pbDoc.Category,
+		Tags:        pbDoc.Tags,
+		UpdatedAt:   pbDoc.UpdatedAt.AsTime().Format("2006-01-02"),
+		ContentB64:  pbDoc.ContentB64,
+		CoverImage:  pbDoc.CoverImage,
+	})
+	if pbDoc.ContentB64 == "" && pbDoc.Stats != nil {
+		doc.Stats = Stats{
+			Words:          int(pbDoc.Stats.Words),
+			ReadingMinutes: int(pbDoc.Stats.ReadingMinutes),
+			CodeBlocks:     int(pbDoc.Stats.CodeBlocks),
+			Sections:       int(pbDoc.Stats.Sections),
+		}
+	}
+	return doc
 }
@@ -0,0 +1,158 @@
+package documents
+
+import (
+	"context"
+	"encoding/base64"
+	"reflect"
+	"slices"
+	"testing"
+
+	pb "markitos-it-app-website/proto"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// fakeDocumentService answers ListDocuments with docs, ignoring the filters
+// like a service that predates them, and records the requests it gets
+type fakeDocumentService struct {
+	docs          []*pb.Document
+	unimplemented bool
+	requests      []*pb.ListDocumentsRequest
+}
+
+func (s *fakeDocumentService) GetAllDocuments(context.Context, *pb.GetAllDocumentsRequest, ...grpc.CallOption) (*pb.GetAllDocumentsResponse, error) {
+	return &pb.GetAllDocumentsResponse{Documents: s.docs, Total: int32(len(s.docs))}, nil
+}
+
+func (s *fakeDocumentService) GetDocumentById(_ context.Context, req *pb.GetDocumentByIdRequest, _ ...grpc.CallOption) (*pb.GetDocumentByIdResponse, error) {
+	for _, doc := range s.docs {
+		if doc.Id == req.Id {
+			return &pb.GetDocumentByIdResponse{Document: doc}, nil
+		}
+	}
+	return nil, status.Error(codes.NotFound, "no such document")
+}
+
+func (s *fakeDocumentService) ListDocuments(_ context.Context, req *pb.ListDocumentsRequest, _ ...grpc.CallOption) (*pb.ListDocumentsResponse, error) {
+	s.requests = append(s.requests, req)
+	if s.unimplemented {
+		return nil, status.Error(codes.Unimplemented, "unknown method")
+	}
+	return &pb.ListDocumentsResponse{Documents: s.docs, TotalSize: int32(len(s.docs))}, nil
+}
+
+func newFakeGRPCRepository(service pb.DocumentServiceClient) *GRPCRepository {
+	opts := DefaultGRPCOptions()
+	opts.Retry.MaxAttempts = 1
+	return &GRPCRepository{
+		client:  service,
+		opts:    opts,
+		breaker: NewCircuitBreaker("test", opts.Breaker),
+	}
+}
+
+func TestGRPCRepositoryListSummariesFilters(t *testing.T) {
+	docs := []*pb.Document{
+		{Id: "k8s", Category: "Infrastructure", Tags: []string{"kubernetes"}, UpdatedAt: timestamppb.Now()},
+		{Id: "cdn", Category: "Infrastructure", Tags: []string{"caching"}, UpdatedAt: timestamppb.Now()},
+		{Id: "grpc", Category: "Backend", Tags: []string{"kubernetes"}, UpdatedAt: timestamppb.Now()},
+	}
+
+	tests := []struct {
+		name          string
+		filter        Query
+		unimplemented bool
+		wantCategory  string
+		wantTags      []string
+		wantIDs       []string
+	}{
+		{
+			name:    "no filter",
+			wantIDs: []string{"k8s", "cdn", "grpc"},
+		},
+		{
+			name:         "category",
+			filter:       Query{Category: "Infrastructure", Text: "ignored"},
+			wantCategory: "Infrastructure",
+			wantIDs:      []string{"k8s", "cdn"},
+		},
+		{
+			name:         "category and tag",
+			filter:       Query{Category: " infrastructure ", Tag: "Kubernetes"},
+			wantCategory: "infrastructure",
+			wantTags:     []string{"Kubernetes"},
+			wantIDs:      []string{"k8s"},
+		},
+		{
+			name:          "legacy service",
+			filter:        Query{Tag: "kubernetes"},
+			unimplemented: true,
+			wantTags:      []string{"kubernetes"},
+			wantIDs:       []string{"k8s", "grpc"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := &fakeDocumentService{docs: docs, unimplemented: tt.unimplemented}
+			got, err := newFakeGRPCRepository(service).ListSummaries(context.Background(), tt.filter)
+			if err != nil {
+				t.Fatalf("ListSummaries error = %v", err)
+			}
+
+			req := service.requests[0]
+			if req.Category != tt.wantCategory || !slices.Equal(req.Tags, tt.wantTags) {
+				t.Errorf("request filters = %q %q, want %q %q", req.Category, req.Tags, tt.wantCategory, tt.wantTags)
+			}
+			if req.View != pb.DocumentView_DOCUMENT_VIEW_SUMMARY {
+				t.Errorf("request view = %v, want summary", req.View)
+			}
+
+			var ids []string
+			for _, doc := range got {
+				ids = append(ids, doc.ID)
+			}
+			if !slices.Equal(ids, tt.wantIDs) {
+				t.Errorf("ListSummaries = %v, want %v", ids, tt.wantIDs)
+			}
+		})
+	}
+}
+
+func TestDocumentFromProtoStats(t *testing.T) {
+	content := base64.StdEncoding.EncodeToString([]byte("## One\n\nTwo words.\n"))
+	serverStats := &pb.DocumentStats{Words: 120, ReadingMinutes: 2, CodeBlocks: 3, Sections: 4}
+
+	tests := []struct {
+		name  string
+		pbDoc *pb.Document
+		want  Stats
+	}{
+		{
+			name:  "summary with server stats",
+			pbDoc: &pb.Document{Id: "a", Stats: serverStats},
+			want:  Stats{Words: 120, ReadingMinutes: 2, CodeBlocks: 3, Sections: 4},
+		},
+		{
+			name:  "summary without stats",
+			pbDoc: &pb.Document{Id: "a"},
+		},
+		{
+			name:  "content wins over server stats",
+			pbDoc: &pb.Document{Id: "a", ContentB64: content, Stats: serverStats},
+			want:  Stats{Words: 3, ReadingMinutes: 1, Sections: 1, Outline: []Heading{{2, "One"}}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.pbDoc.UpdatedAt = timestamppb.Now()
+			if got := documentFromProto(tt.pbDoc).Stats; !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Stats = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -9,4 +9,16 @@ type Document struct {
 	UpdatedAt   string
 	ContentB64  string
 	CoverImage  string
+	// Stats are computed from the content when it is loaded, and kept in summaries
+	Stats Stats
+}
+
+// summaries returns copies of docs without their content
+func summaries(docs []Document) []Document {
+	out := make([]Document, len(docs))
+	for i, doc := range docs {
+		doc.ContentB64 = ""
+		out[i] = doc
+	}
+	return out
 }
@@ -0,0 +1,176 @@
+package documents
+
+import (
+	"slices"
+	"strings"
+)
+
+// SortOrder selects how a document listing is ordered
+type SortOrder string
+
+const (
+	// SortUpdated lists the most recently updated documents first
+	SortUpdated SortOrder = "updated"
+	// SortTitle lists documents alphabetically by title
+	SortTitle SortOrder = "title"
+)
+
+const (
+	DefaultPerPage = 12
+	MaxPerPage     = 100
+)
+
+// Query filters, sorts and paginates a document listing.
+// Zero values mean "no filter", SortUpdated, the first page and DefaultPerPage.
+type Query struct {
+	Category string
+	Tag      string
+	// Text is matched case-insensitively against title, description and tags
+	Text    string
+	Sort    SortOrder
+	Page    int
+	PerPage int
+}
+
+// Normalize fills in defaults and clamps out-of-range values
+func (q Query) Normalize() Query {
+	q.Category = strings.TrimSpace(q.Category)
+	q.Tag = strings.TrimSpace(q.Tag)
+	q.Text = strings.TrimSpace(q.Text)
+	if q.Sort != SortTitle {
+		q.Sort = SortUpdated
+	}
+	if q.Page < 1 {
+		q.Page = 1
+	}
+	if q.PerPage < 1 {
+		q.PerPage = DefaultPerPage
+	}
+	if q.PerPage > MaxPerPage {
+		q.PerPage = MaxPerPage
+	}
+	return q
+}
+
+// Page is one page of a query result
+type Page struct {
+	Documents  []Document
+	Total      int
+	Page       int
+	PerPage    int
+	TotalPages int
+}
+
+// HasPrev reports whether there is a page before this one
+func (p Page) HasPrev() bool {
+	return p.Page > 1
+}
+
+// HasNext reports whether there is a page after this one
+func (p Page) HasNext() bool {
+	return p.Page < p.TotalPages
+}
+
+// ApplyQuery filters, sorts and paginates docs without modifying it.
+// A page past the end is clamped to the last page.
+func ApplyQuery(docs []Document, q Query) Page {
+	q = q.Normalize()
+
+	matched := make([]Document, 0, len(docs))
+	for _, doc := range docs {
+		if q.matches(doc) {
+			matched = append(matched, doc)
+		}
+	}
+
+	switch q.Sort {
+	case SortTitle:
+		slices.SortStableFunc(matched, func(a, b Document) int {
+			return strings.Compare(strings.ToLower(a.Title), strings.ToLower(b.Title))
+		})
+	default:
+		// UpdatedAt is YYYY-MM-DD, so string order is date order
+		slices.SortStableFunc(matched, func(a, b Document) int {
+			return strings.Compare(b.UpdatedAt, a.UpdatedAt)
+		})
+	}
+
+	totalPages := max((len(matched)+q.PerPage-1)/q.PerPage, 1)
+	page := min(q.Page, totalPages)
+	start := min((page-1)*q.PerPage, len(matched))
+	end := min(start+q.PerPage, len(matched))
+
+	return Page{
+		Documents:  matched[start:end],
+		Total:      len(matched),
+		Page:       page,
+		PerPage:    q.PerPage,
+		TotalPages: totalPages,
+	}
+}
+
+func (q Query) matches(doc Document) bool {
+	if q.Category != "" && !strings.EqualFold(doc.Category, q.Category) {
+		return false
+	}
+	if q.Tag != "" && !slices.ContainsFunc(doc.Tags, func(t string) bool { return strings.EqualFold(t, q.Tag) }) {
+		return false
+	}
+	if q.Text != "" {
+		text := strings.ToLower(q.Text)
+		if !strings.Contains(strings.ToLower(doc.Title), text) &&
+			!strings.Contains(strings.ToLower(doc.Description), text) &&
+			!slices.ContainsFunc(doc.Tags, func(t string) bool { return strings.Contains(strings.ToLower(t), text) }) {
+			return false
+		}
+	}
+	return true
+}
+
+// Filter keeps only the category and tag of q, the part of a query that
+// Repository.ListSummaries applies
+func (q Query) Filter() Query {
+	return Query{Category: strings.TrimSpace(q.Category), Tag: strings.TrimSpace(q.Tag)}
+}
+
+// filterDocuments returns the docs in the category and with the tag of
+// filter, or docs itself when filter has neither
+func filterDocuments(docs []Document, filter Query) []Document {
+	filter = filter.Filter()
+	if filter.Category == "" && filter.Tag == "" {
+		return docs
+	}
+
+	matched := make([]Document, 0, len(docs))
+	for _, doc := range docs {
+		if filter.matches(doc) {
+			matched = append(matched, doc)
+		}
+	}
+	return matched
+}
+
+// Categories returns the distinct categories of docs in first-seen order
+func Categories(docs []Document) []string {
+	var categories []string
+	for _, doc := range docs {
+		if !slices.Contains(categories, doc.Category) {
+			categories = append(categories, doc.Category)
+		}
+	}
+	return categories
+}
+
+// Tags returns the distinct tags of docs sorted alphabetically
+func Tags(docs []Document) []string {
+	var tags []string
+	for _, doc := range docs {
+		for _, tag := range doc.Tags {
+			if !slices.Contains(tags, tag) {
+				tags = append(tags, tag)
+			}
+		}
+	}
+	slices.Sort(tags)
+	return tags
+}
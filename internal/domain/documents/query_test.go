@@ -0,0 +1,253 @@
+package documents
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+)
+
+func TestQueryNormalize(t *testing.T) {
+	tests := []struct {
+		name  string
+		query Query
+		want  Query
+	}{
+		{
+			name:  "defaults",
+			query: Query{},
+			want:  Query{Sort: SortUpdated, Page: 1, PerPage: DefaultPerPage},
+		},
+		{
+			name:  "trims filters",
+			query: Query{Category: " Backend ", Tag: "\tgo\n", Text: "  grpc "},
+			want:  Query{Category: "Backend", Tag: "go", Text: "grpc", Sort: SortUpdated, Page: 1, PerPage: DefaultPerPage},
+		},
+		{
+			name:  "title sort kept",
+			query: Query{Sort: SortTitle, Page: 3, PerPage: 5},
+			want:  Query{Sort: SortTitle, Page: 3, PerPage: 5},
+		},
+		{
+			name:  "unknown sort",
+			query: Query{Sort: "random"},
+			want:  Query{Sort: SortUpdated, Page: 1, PerPage: DefaultPerPage},
+		},
+		{
+			name:  "negative page and per page",
+			query: Query{Page: -2, PerPage: -1},
+			want:  Query{Sort: SortUpdated, Page: 1, PerPage: DefaultPerPage},
+		},
+		{
+			name:  "per page above the maximum",
+			query: Query{PerPage: MaxPerPage + 1},
+			want:  Query{Sort: SortUpdated, Page: 1, PerPage: MaxPerPage},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.query.Normalize(); got != tt.want {
+				t.Errorf("Normalize() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestApplyQueryFilters(t *testing.T) {
+	docs := []Document{
+		{ID: "k8s", Title: "Kubernetes basics", Category: "Infrastructure", Tags: []string{"kubernetes", "containers"}, UpdatedAt: "2025-03-01"},
+		{ID: "helm", Title: "Helm charts", Description: "Packaging for Kubernetes", Category: "Infrastructure", Tags: []string{"kubernetes"}, UpdatedAt: "2025-01-15"},
+		{ID: "cdn", Title: "CDN caching", Category: "Infrastructure", Tags: []string{"caching"}, UpdatedAt: "2025-02-10"},
+		{ID: "grpc", Title: "gRPC services", Category: "Backend", Tags: []string{"go", "containers"}, UpdatedAt: "2025-02-20"},
+	}
+
+	tests := []struct {
+		name  string
+		query Query
+		want  []string
+	}{
+		{
+			name: "everything, newest first",
+			want: []string{"k8s", "grpc", "cdn", "helm"},
+		},
+		{
+			name:  "everything by title",
+			query: Query{Sort: SortTitle},
+			want:  []string{"cdn", "grpc", "helm", "k8s"},
+		},
+		{
+			name:  "category ignores case",
+			query: Query{Category: "infrastructure"},
+			want:  []string{"k8s", "cdn", "helm"},
+		},
+		{
+			name:  "tag ignores case",
+			query: Query{Tag: "Containers"},
+			want:  []string{"k8s", "grpc"},
+		},
+		{
+			name:  "category and tag",
+			query: Query{Category: "Infrastructure", Tag: "containers"},
+			want:  []string{"k8s"},
+		},
+		{
+			name:  "text matches title, description and tags",
+			query: Query{Text: "KUBERNETES"},
+			want:  []string{"k8s", "helm"},
+		},
+		{
+			name:  "category, tag and text",
+			query: Query{Category: "Infrastructure", Tag: "kubernetes", Text: "packaging", Sort: SortTitle},
+			want:  []string{"helm"},
+		},
+		{
+			name:  "category and text without a match",
+			query: Query{Category: "Backend", Text: "caching"},
+			want:  []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			page := ApplyQuery(docs, tt.query)
+			ids := []string{}
+			for _, doc := range page.Documents {
+				ids = append(ids, doc.ID)
+			}
+			if !slices.Equal(ids, tt.want) || page.Total != len(tt.want) {
+				t.Errorf("ApplyQuery = %v (total %d), want %v", ids, page.Total, tt.want)
+			}
+		})
+	}
+
+	if docs[0].ID != "k8s" || docs[3].ID != "grpc" {
+		t.Error("ApplyQuery reordered its input")
+	}
+}
+
+func TestApplyQueryPagination(t *testing.T) {
+	docs := make([]Document, 25)
+	for i := range docs {
+		// Newest first is the reverse of creation order
+		docs[i] = Document{ID: fmt.Sprintf("doc-%02d", i), UpdatedAt: fmt.Sprintf("2025-01-%02d", i+1)}
+	}
+
+	tests := []struct {
+		name        string
+		docs        []Document
+		query       Query
+		wantPage    int
+		wantPages   int
+		wantFirst   string
+		wantLen     int
+		wantPrev    bool
+		wantNext    bool
+		wantPerPage int
+	}{
+		{
+			name:        "first page",
+			docs:        docs,
+			query:       Query{PerPage: 10},
+			wantPage:    1,
+			wantPages:   3,
+			wantFirst:   "doc-24",
+			wantLen:     10,
+			wantNext:    true,
+			wantPerPage: 10,
+		},
+		{
+			name:        "middle page",
+			docs:        docs,
+			query:       Query{Page: 2, PerPage: 10},
+			wantPage:    2,
+			wantPages:   3,
+			wantFirst:   "doc-14",
+			wantLen:     10,
+			wantPrev:    true,
+			wantNext:    true,
+			wantPerPage: 10,
+		},
+		{
+			name:        "partial last page",
+			docs:        docs,
+			query:       Query{Page: 3, PerPage: 10},
+			wantPage:    3,
+			wantPages:   3,
+			wantFirst:   "doc-04",
+			wantLen:     5,
+			wantPrev:    true,
+			wantPerPage: 10,
+		},
+		{
+			name:        "page past the end is clamped",
+			docs:        docs,
+			query:       Query{Page: 99, PerPage: 10},
+			wantPage:    3,
+			wantPages:   3,
+			wantFirst:   "doc-04",
+			wantLen:     5,
+			wantPrev:    true,
+			wantPerPage: 10,
+		},
+		{
+			name:        "default per page",
+			docs:        docs,
+			wantPage:    1,
+			wantPages:   3,
+			wantFirst:   "doc-24",
+			wantLen:     DefaultPerPage,
+			wantNext:    true,
+			wantPerPage: DefaultPerPage,
+		},
+		{
+			name:        "per page clamped to the maximum",
+			docs:        docs,
+			query:       Query{PerPage: 1000},
+			wantPage:    1,
+			wantPages:   1,
+			wantFirst:   "doc-24",
+			wantLen:     25,
+			wantPerPage: MaxPerPage,
+		},
+		{
+			name:        "exact multiple",
+			docs:        docs[:20],
+			query:       Query{Page: 2, PerPage: 10},
+			wantPage:    2,
+			wantPages:   2,
+			wantFirst:   "doc-09",
+			wantLen:     10,
+			wantPrev:    true,
+			wantPerPage: 10,
+		},
+		{
+			name:        "no documents is one empty page",
+			query:       Query{Page: 4},
+			wantPage:    1,
+			wantPages:   1,
+			wantPerPage: DefaultPerPage,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			page := ApplyQuery(tt.docs, tt.query)
+			if page.Page != tt.wantPage || page.TotalPages != tt.wantPages || page.PerPage != tt.wantPerPage {
+				t.Errorf("page %d of %d (%d per page), want %d of %d (%d per page)",
+					page.Page, page.TotalPages, page.PerPage, tt.wantPage, tt.wantPages, tt.wantPerPage)
+			}
+			if page.Total != len(tt.docs) {
+				t.Errorf("Total = %d, want %d", page.Total, len(tt.docs))
+			}
+			if len(page.Documents) != tt.wantLen {
+				t.Fatalf("got %d documents, want %d", len(page.Documents), tt.wantLen)
+			}
+			if tt.wantLen > 0 && page.Documents[0].ID != tt.wantFirst {
+				t.Errorf("first document = %s, want %s", page.Documents[0].ID, tt.wantFirst)
+			}
+			if page.HasPrev() != tt.wantPrev || page.HasNext() != tt.wantNext {
+				t.Errorf("HasPrev, HasNext = %v, %v; want %v, %v", page.HasPrev(), page.HasNext(), tt.wantPrev, tt.wantNext)
+			}
+		})
+	}
+}
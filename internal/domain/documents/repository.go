@@ -0,0 +1,91 @@
+package documents
+
+import (
+	"context"
+	"errors"
+	"log"
+	"log/slog"
+)
+
+// Repository is the read-only source of documents used by the handlers
+type Repository interface {
+	// List returns every available document
+	List(ctx context.Context) ([]Document, error)
+	// ListSummaries returns the documents in filter's category and with its
+	// tag, without their content, which is all listing pages need. The other
+	// fields of filter are ignored; a zero Query lists every document.
+	ListSummaries(ctx context.Context, filter Query) ([]Document, error)
+	// Get returns the document with the given ID. It fails with ErrNotFound
+	// if the document does not exist, ErrInvalidID if the ID is malformed and
+	// ErrUnavailable if the source cannot be reached.
+	Get(ctx context.Context, id string) (*Document, error)
+}
+
+// FallbackRepository queries a primary repository and falls back to a
+// secondary one when the primary is unavailable
+type FallbackRepository struct {
+	primary   Repository
+	secondary Repository
+}
+
+// NewFallbackRepository creates a repository that uses secondary whenever primary returns ErrUnavailable
+func NewFallbackRepository(primary, secondary Repository) *FallbackRepository {
+	return &FallbackRepository{
+		primary:   primary,
+		secondary: secondary,
+	}
+}
+
+// List returns the documents from the primary repository, or from the secondary one if it is unavailable
+func (r *FallbackRepository) List(ctx context.Context) ([]Document, error) {
+	docs, err := r.primary.List(ctx)
+	if err == nil {
+		log.Printf("✅ Loaded %d documents from primary source", len(docs))
+		return docs, nil
+	}
+	if !errors.Is(err, ErrUnavailable) {
+		return nil, err
+	}
+
+	slog.Warn("Documents service unavailable, listing fallback documents", "err", err)
+
+	return r.secondary.List(ctx)
+}
+
+// ListSummaries returns the summaries from the primary repository, or from the secondary one if it is unavailable
+func (r *FallbackRepository) ListSummaries(ctx context.Context, filter Query) ([]Document, error) {
+	docs, err := r.primary.ListSummaries(ctx, filter)
+	if err == nil {
+		return docs, nil
+	}
+	if !errors.Is(err, ErrUnavailable) {
+		return nil, err
+	}
+
+	slog.Warn("Documents service unavailable, listing fallback summaries", "err", err)
+
+	return r.secondary.ListSummaries(ctx, filter)
+}
+
+// Get returns a document from the primary repository, or from the secondary one if it is unavailable.
+// A document the secondary does not have may still exist in the primary, so
+// that miss is reported as the primary's ErrUnavailable rather than ErrNotFound.
+func (r *FallbackRepository) Get(ctx context.Context, id string) (*Document, error) {
+	doc, err := r.primary.Get(ctx, id)
+	if err == nil {
+		log.Printf("✅ Document '%s' loaded from primary source", id)
+		return doc, nil
+	}
+	if !errors.Is(err, ErrUnavailable) {
+		return nil, err
+	}
+
+	slog.Warn("Documents service unavailable, loading fallback document", "id", id, "err", err)
+	log.Println("📚 Searching in fallback source...")
+
+	doc, fallbackErr := r.secondary.Get(ctx, id)
+	if errors.Is(fallbackErr, ErrNotFound) {
+		return nil, err
+	}
+	return doc, fallbackErr
+}
@@ -0,0 +1,90 @@
+package documents
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+)
+
+// stubRepository answers every call with docs, or with err when set
+type stubRepository struct {
+	docs []Document
+	err  error
+}
+
+func (r stubRepository) List(context.Context) ([]Document, error) {
+	return r.docs, r.err
+}
+
+func (r stubRepository) ListSummaries(_ context.Context, filter Query) ([]Document, error) {
+	return summaries(filterDocuments(r.docs, filter)), r.err
+}
+
+func (r stubRepository) Get(_ context.Context, id string) (*Document, error) {
+	if r.err != nil {
+		return nil, r.err
+	}
+	for _, doc := range r.docs {
+		if doc.ID == id {
+			return &doc, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: %s", ErrNotFound, id)
+}
+
+func TestFallbackRepositoryGet(t *testing.T) {
+	unavailable := stubRepository{err: fmt.Errorf("%w: connection refused", ErrUnavailable)}
+	embedded := stubRepository{docs: []Document{{ID: "embedded"}}}
+
+	tests := []struct {
+		name    string
+		primary Repository
+		id      string
+		wantID  string
+		wantErr error
+	}{
+		{
+			name:    "primary answers",
+			primary: stubRepository{docs: []Document{{ID: "remote"}}},
+			id:      "remote",
+			wantID:  "remote",
+		},
+		{
+			name:    "primary miss is not found",
+			primary: stubRepository{},
+			id:      "embedded",
+			wantErr: ErrNotFound,
+		},
+		{
+			name:    "primary down, fallback has it",
+			primary: unavailable,
+			id:      "embedded",
+			wantID:  "embedded",
+		},
+		{
+			name:    "primary down, fallback miss stays unavailable",
+			primary: unavailable,
+			id:      "remote-only",
+			wantErr: ErrUnavailable,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc, err := NewFallbackRepository(tt.primary, embedded).Get(context.Background(), tt.id)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("Get(%q) error = %v, want %v", tt.id, err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Get(%q) error = %v", tt.id, err)
+			}
+			if doc.ID != tt.wantID {
+				t.Errorf("Get(%q) = %q, want %q", tt.id, doc.ID, tt.wantID)
+			}
+		})
+	}
+}
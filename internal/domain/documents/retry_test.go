@@ -0,0 +1,80 @@
+package documents
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestRetryPolicyDo(t *testing.T) {
+	unavailable := status.Error(codes.Unavailable, "down")
+
+	tests := []struct {
+		name      string
+		attempts  int
+		errs      []error
+		wantCalls int
+		wantErr   error
+	}{
+		{name: "first attempt succeeds", attempts: 3, errs: []error{nil}, wantCalls: 1},
+		{name: "succeeds after retries", attempts: 3, errs: []error{unavailable, status.Error(codes.Aborted, "conflict"), nil}, wantCalls: 3},
+		{name: "retries resource exhausted", attempts: 2, errs: []error{status.Error(codes.ResourceExhausted, "busy"), nil}, wantCalls: 2},
+		{name: "gives up after max attempts", attempts: 3, errs: []error{unavailable, unavailable, unavailable}, wantCalls: 3, wantErr: unavailable},
+		{name: "zero attempts still calls once", attempts: 0, errs: []error{unavailable}, wantCalls: 1, wantErr: unavailable},
+		{name: "not found is not retried", attempts: 3, errs: []error{status.Error(codes.NotFound, "missing")}, wantCalls: 1, wantErr: status.Error(codes.NotFound, "missing")},
+		{name: "deadline exceeded is not retried", attempts: 3, errs: []error{status.Error(codes.DeadlineExceeded, "slow")}, wantCalls: 1, wantErr: status.Error(codes.DeadlineExceeded, "slow")},
+		{name: "internal is not retried", attempts: 3, errs: []error{status.Error(codes.Internal, "bug")}, wantCalls: 1, wantErr: status.Error(codes.Internal, "bug")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls := 0
+			err := RetryPolicy{MaxAttempts: tt.attempts}.do(context.Background(), func(context.Context) error {
+				calls++
+				return tt.errs[calls-1]
+			})
+			if calls != tt.wantCalls {
+				t.Errorf("call ran %d times, want %d", calls, tt.wantCalls)
+			}
+			if status.Code(err) != status.Code(tt.wantErr) || (err == nil) != (tt.wantErr == nil) {
+				t.Errorf("do() = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestRetryPolicyDoStopsWhenContextDone(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	unavailable := status.Error(codes.Unavailable, "down")
+
+	calls := 0
+	policy := RetryPolicy{MaxAttempts: 5, InitialBackoff: time.Hour, MaxBackoff: time.Hour}
+	err := policy.do(ctx, func(context.Context) error {
+		calls++
+		cancel()
+		return unavailable
+	})
+
+	if calls != 1 || !errors.Is(err, unavailable) {
+		t.Errorf("do() = %v after %d calls, want the first error after 1 call", err, calls)
+	}
+}
+
+func TestRetryPolicyBackoff(t *testing.T) {
+	policy := RetryPolicy{InitialBackoff: 10 * time.Millisecond, MaxBackoff: 50 * time.Millisecond}
+	for retry := 1; retry <= 10; retry++ {
+		ceiling := min(policy.InitialBackoff<<(retry-1), policy.MaxBackoff)
+		for range 20 {
+			if d := policy.backoff(retry); d < 0 || d >= ceiling {
+				t.Fatalf("backoff(%d) = %s, want in [0, %s)", retry, d, ceiling)
+			}
+		}
+	}
+	if d := (RetryPolicy{}).backoff(1); d != 0 {
+		t.Errorf("backoff without durations = %s, want 0", d)
+	}
+}
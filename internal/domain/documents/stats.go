@@ -0,0 +1,100 @@
+package documents
+
+import (
+	"encoding/base64"
+	"math"
+	"strings"
+	"unicode"
+
+	"markitos-it-app-website/internal/mdtext"
+
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/extension"
+	"github.com/yuin/goldmark/text"
+)
+
+// Conservative reading speeds for technical writing: prose is read, code is skimmed
+const (
+	wordsPerMinute     = 200
+	codeLinesPerMinute = 50
+)
+
+// Stats describes the content of a document. Summaries from the documents
+// service carry the counts but no Outline.
+type Stats struct {
+	Words          int
+	ReadingMinutes int
+	CodeBlocks     int
+	// Sections is the number of top-level sections (h2)
+	Sections int
+	Outline  []Heading
+}
+
+// Heading is an entry of a document outline
+type Heading struct {
+	Level int
+	Text  string
+}
+
+// statsParser reads documents the way the site renders them, GFM included,
+// so headings and code blocks are recognised exactly as they are displayed
+var statsParser = goldmark.New(goldmark.WithExtensions(extension.GFM)).Parser()
+
+// ComputeStats analyses a markdown body. Words inside code blocks are not
+// counted as words; their lines add to the reading time instead.
+func ComputeStats(markdown []byte) Stats {
+	var stats Stats
+	codeLines := 0
+
+	doc := statsParser.Parse(text.NewReader(markdown))
+	_ = ast.Walk(doc, func(n ast.Node, entering bool) (ast.WalkStatus, error) {
+		if !entering {
+			return ast.WalkContinue, nil
+		}
+		switch n := n.(type) {
+		case *ast.FencedCodeBlock, *ast.CodeBlock:
+			stats.CodeBlocks++
+			codeLines += n.Lines().Len()
+			return ast.WalkSkipChildren, nil
+		case *ast.Heading:
+			stats.Outline = append(stats.Outline, Heading{Level: n.Level, Text: mdtext.PlainText(n, markdown)})
+			if n.Level == 2 {
+				stats.Sections++
+			}
+		case *ast.Text:
+			stats.Words += countWords(string(n.Segment.Value(markdown)))
+		case *ast.String:
+			stats.Words += countWords(string(n.Value))
+		}
+		return ast.WalkContinue, nil
+	})
+
+	if stats.Words > 0 || codeLines > 0 {
+		minutes := float64(stats.Words)/wordsPerMinute + float64(codeLines)/codeLinesPerMinute
+		stats.ReadingMinutes = int(math.Ceil(minutes))
+	}
+	return stats
+}
+
+// withStats returns doc with its Stats computed from the content
+func withStats(doc Document) Document {
+	content, err := base64.StdEncoding.DecodeString(doc.ContentB64)
+	if err != nil || len(content) == 0 {
+		return doc
+	}
+	doc.Stats = ComputeStats(content)
+	return doc
+}
+
+// countWords counts the fields of line that contain a letter or a digit,
+// which skips markdown punctuation such as list bullets and table pipes
+func countWords(line string) int {
+	n := 0
+	for _, field := range strings.Fields(line) {
+		if strings.IndexFunc(field, func(r rune) bool { return unicode.IsLetter(r) || unicode.IsDigit(r) }) >= 0 {
+			n++
+		}
+	}
+	return n
+}
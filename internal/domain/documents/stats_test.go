@@ -0,0 +1,95 @@
+package documents
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestComputeStatsOutline(t *testing.T) {
+	tests := []struct {
+		name     string
+		markdown string
+		want     []Heading
+	}{
+		{
+			name:     "atx headings",
+			markdown: "# Title\n\n## Setup ##\n\n### Install\n",
+			want:     []Heading{{1, "Title"}, {2, "Setup"}, {3, "Install"}},
+		},
+		{
+			name:     "setext headings",
+			markdown: "Title\n=====\n\nSetup\n-----\n",
+			want:     []Heading{{1, "Title"}, {2, "Setup"}},
+		},
+		{
+			name:     "markup in headings",
+			markdown: "## The **`go`** [tool](https://go.dev)\n",
+			want:     []Heading{{2, "The go tool"}},
+		},
+		{
+			name:     "hash lines in indented code",
+			markdown: "## Shell\n\n    # install\n    make install\n",
+			want:     []Heading{{2, "Shell"}},
+		},
+		{
+			name:     "hash lines in fenced code",
+			markdown: "## Shell\n\n```sh\n# install\n```\n",
+			want:     []Heading{{2, "Shell"}},
+		},
+		{
+			name:     "hash without a space",
+			markdown: "#hashtag\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ComputeStats([]byte(tt.markdown)).Outline; !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Outline = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestComputeStatsCounts(t *testing.T) {
+	tests := []struct {
+		name       string
+		markdown   string
+		words      int
+		codeBlocks int
+		minutes    int
+	}{
+		{
+			name:     "prose",
+			markdown: "## Intro\n\nOne *two* three.\n\n- four\n- five\n",
+			words:    6,
+			minutes:  1,
+		},
+		{
+			name:       "code is not counted as words",
+			markdown:   "Run it:\n\n```go\nfunc main() {}\n```\n\n    indented code\n",
+			words:      2,
+			codeBlocks: 2,
+			minutes:    1,
+		},
+		{
+			name:     "table cells",
+			markdown: "| a | b |\n|---|---|\n| c | d |\n",
+			words:    4,
+			minutes:  1,
+		},
+		{
+			name: "empty",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stats := ComputeStats([]byte(tt.markdown))
+			if stats.Words != tt.words || stats.CodeBlocks != tt.codeBlocks || stats.ReadingMinutes != tt.minutes {
+				t.Errorf("ComputeStats = %d words, %d code blocks, %d min; want %d, %d, %d",
+					stats.Words, stats.CodeBlocks, stats.ReadingMinutes, tt.words, tt.codeBlocks, tt.minutes)
+			}
+		})
+	}
+}
@@ -0,0 +1,138 @@
+package search
+
+import (
+	"strings"
+	"unicode"
+
+	snowball "github.com/blevesearch/snowballstem"
+	"github.com/blevesearch/snowballstem/english"
+	"github.com/blevesearch/snowballstem/spanish"
+	"golang.org/x/text/runes"
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
+)
+
+// Language selects the stemmer used for a text
+type Language int
+
+const (
+	English Language = iota
+	Spanish
+)
+
+var englishStopwords = wordSet(`a an and are as at be but by for from has have how if in into is it its
+	of on or so than that the their then there these this to was were what when where which who will with you your`)
+
+var spanishStopwords = wordSet(`a al con como cual cuando de del desde donde el ella en entre es esta este
+	esto la las lo los mas no o para pero por que se si sin sobre su sus un una uno unos y ya`)
+
+func wordSet(words string) map[string]bool {
+	set := make(map[string]bool)
+	for _, w := range strings.Fields(words) {
+		set[w] = true
+	}
+	return set
+}
+
+// token is a word of the original text together with its position
+type token struct {
+	word       string
+	start, end int
+}
+
+// tokenize splits text into lowercase words of letters and digits
+func tokenize(text string) []token {
+	var tokens []token
+	start := -1
+	for i, r := range text {
+		isWord := unicode.IsLetter(r) || unicode.IsDigit(r)
+		switch {
+		case isWord && start < 0:
+			start = i
+		case !isWord && start >= 0:
+			tokens = append(tokens, token{word: strings.ToLower(text[start:i]), start: start, end: i})
+			start = -1
+		}
+	}
+	if start >= 0 {
+		tokens = append(tokens, token{word: strings.ToLower(text[start:]), start: start, end: len(text)})
+	}
+	return tokens
+}
+
+// detectLanguage guesses whether text is Spanish or English by counting stopwords
+func detectLanguage(text string) Language {
+	en, es := 0, 0
+	for _, t := range tokenize(text) {
+		if englishStopwords[t.word] {
+			en++
+		}
+		if spanishStopwords[t.word] {
+			es++
+		}
+	}
+	if es > en {
+		return Spanish
+	}
+	return English
+}
+
+func isStopword(word string) bool {
+	return englishStopwords[word] || spanishStopwords[word]
+}
+
+// stem strips the diacritics of a lowercase word and reduces it to its stem
+// in lang. Folding first means "configuración" and "configuracion", as
+// queries are often typed, end up as the same term.
+func stem(word string, lang Language) string {
+	env := snowball.NewEnv(foldDiacritics(word))
+	switch lang {
+	case Spanish:
+		spanish.Stem(env)
+	default:
+		english.Stem(env)
+	}
+	return env.Current()
+}
+
+var diacriticsFolder = transform.Chain(norm.NFD, runes.Remove(runes.In(unicode.Mn)), norm.NFC)
+
+func foldDiacritics(s string) string {
+	folded, _, err := transform.String(diacriticsFolder, s)
+	if err != nil {
+		return s
+	}
+	return folded
+}
+
+// analyze turns text into index terms in lang, dropping stopwords
+func analyze(text string, lang Language) []string {
+	tokens := tokenize(text)
+	terms := make([]string, 0, len(tokens))
+	for _, t := range tokens {
+		if isStopword(t.word) {
+			continue
+		}
+		terms = append(terms, stem(t.word, lang))
+	}
+	return terms
+}
+
+// queryTerms analyzes a user query. The language of a short query cannot be
+// detected reliably, so every word is stemmed in both languages and each
+// resulting group matches if any of its variants does.
+func queryTerms(query string) [][]string {
+	var groups [][]string
+	for _, t := range tokenize(query) {
+		if isStopword(t.word) {
+			continue
+		}
+		en, es := stem(t.word, English), stem(t.word, Spanish)
+		if en == es {
+			groups = append(groups, []string{en})
+		} else {
+			groups = append(groups, []string{en, es})
+		}
+	}
+	return groups
+}
@@ -0,0 +1,106 @@
+package search
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestAnalyze(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		lang Language
+		want []string
+	}{
+		{
+			name: "english stems and stopwords",
+			text: "Running the containers in Kubernetes clusters",
+			lang: English,
+			want: []string{"run", "contain", "kubernet", "cluster"},
+		},
+		{
+			name: "spanish stems and stopwords",
+			text: "Los despliegues de las aplicaciones",
+			lang: Spanish,
+			want: []string{"desplieg", "aplic"},
+		},
+		{
+			name: "accents are folded",
+			text: "Migración rápida",
+			lang: Spanish,
+			want: []string{"migracion", "rap"},
+		},
+		{
+			name: "punctuation and case",
+			text: "gRPC, HTTP/2 & TLS-1.3!",
+			lang: English,
+			want: []string{"grpc", "http", "2", "tls", "1", "3"},
+		},
+		{
+			name: "only stopwords",
+			text: "the of and",
+			lang: English,
+			want: []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := analyze(tt.text, tt.lang); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("analyze(%q) = %q, want %q", tt.text, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTokenizeOffsets(t *testing.T) {
+	text := "Añadir ÍNDICES, ya"
+	want := []token{{"añadir", 0, 7}, {"índices", 8, 16}, {"ya", 18, 20}}
+
+	got := tokenize(text)
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("tokenize(%q) = %v, want %v", text, got, want)
+	}
+	for _, tok := range got {
+		if !strings.EqualFold(text[tok.start:tok.end], tok.word) {
+			t.Errorf("token %q spans %q", tok.word, text[tok.start:tok.end])
+		}
+	}
+}
+
+func TestDetectLanguage(t *testing.T) {
+	tests := []struct {
+		text string
+		want Language
+	}{
+		{"el despliegue de las aplicaciones en la nube", Spanish},
+		{"the deployment of the apps in the cloud", English},
+		{"kubernetes", English},
+	}
+
+	for _, tt := range tests {
+		if got := detectLanguage(tt.text); got != tt.want {
+			t.Errorf("detectLanguage(%q) = %v, want %v", tt.text, got, tt.want)
+		}
+	}
+}
+
+func TestQueryTerms(t *testing.T) {
+	tests := []struct {
+		query string
+		want  [][]string
+	}{
+		{"", nil},
+		{"the of y de", nil},
+		{"kubernetes", [][]string{{"kubernet"}}},
+		{"configuracion", [][]string{{"configuracion"}}},
+		{"despliegues", [][]string{{"despliegu", "desplieg"}}},
+	}
+
+	for _, tt := range tests {
+		if got := queryTerms(tt.query); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("queryTerms(%q) = %q, want %q", tt.query, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,186 @@
+package search
+
+import (
+	"encoding/base64"
+	"html/template"
+	"math"
+	"regexp"
+	"slices"
+	"strings"
+
+	"markitos-it-app-website/internal/domain/documents"
+)
+
+// BM25 parameters
+const (
+	bm25K1 = 1.2
+	bm25B  = 0.75
+)
+
+// Field weights: a match in the title counts as much as three in the body
+const (
+	titleWeight       = 3
+	tagsWeight        = 2
+	descriptionWeight = 2
+	categoryWeight    = 2
+	bodyWeight        = 1
+)
+
+// Result is a document matching a search, best matches first
+type Result struct {
+	Document documents.Document
+	Score    float64
+	// Snippet is an excerpt of the body with the matched words in <mark>
+	Snippet template.HTML
+}
+
+type posting struct {
+	doc int
+	tf  float64
+}
+
+type indexedDoc struct {
+	doc    documents.Document
+	body   string
+	length float64
+}
+
+// Index is an immutable inverted index over document metadata and bodies
+type Index struct {
+	docs      []indexedDoc
+	postings  map[string][]posting
+	avgLength float64
+}
+
+// NewIndex builds an index from docs. The content of each document is
+// decoded and indexed together with its title, description, category and tags.
+func NewIndex(docs []documents.Document) *Index {
+	idx := &Index{
+		docs:     make([]indexedDoc, len(docs)),
+		postings: make(map[string][]posting),
+	}
+
+	var totalLength float64
+	for i, doc := range docs {
+		content, _ := base64.StdEncoding.DecodeString(doc.ContentB64)
+		body := plainText(string(content))
+		lang := detectLanguage(body)
+
+		freqs := make(map[string]float64)
+		var length float64
+		addField := func(text string, weight float64) {
+			for _, term := range analyze(text, lang) {
+				freqs[term] += weight
+				length += weight
+			}
+		}
+		addField(doc.Title, titleWeight)
+		addField(doc.Description, descriptionWeight)
+		addField(doc.Category, categoryWeight)
+		addField(strings.Join(doc.Tags, " "), tagsWeight)
+		addField(body, bodyWeight)
+
+		for term, tf := range freqs {
+			idx.postings[term] = append(idx.postings[term], posting{doc: i, tf: tf})
+		}
+
+		summary := doc
+		summary.ContentB64 = ""
+		idx.docs[i] = indexedDoc{doc: summary, body: body, length: length}
+		totalLength += length
+	}
+
+	if len(docs) > 0 {
+		idx.avgLength = totalLength / float64(len(docs))
+	}
+
+	return idx
+}
+
+// Len returns the number of indexed documents
+func (idx *Index) Len() int {
+	return len(idx.docs)
+}
+
+// Search ranks the documents matching any word of query with BM25 and
+// returns at most limit results
+func (idx *Index) Search(query string, limit int) []Result {
+	groups := queryTerms(query)
+	if len(groups) == 0 || len(idx.docs) == 0 {
+		return nil
+	}
+
+	scores := make(map[int]float64)
+	matched := make(map[string]bool)
+	n := float64(len(idx.docs))
+
+	for _, group := range groups {
+		// Score each query word once, using its best-scoring stem variant
+		best := make(map[int]float64)
+		for _, term := range group {
+			postings := idx.postings[term]
+			if len(postings) == 0 {
+				continue
+			}
+			matched[term] = true
+
+			df := float64(len(postings))
+			idf := math.Log(1 + (n-df+0.5)/(df+0.5))
+			for _, p := range postings {
+				norm := 1 - bm25B + bm25B*idx.docs[p.doc].length/idx.avgLength
+				score := idf * p.tf * (bm25K1 + 1) / (p.tf + bm25K1*norm)
+				best[p.doc] = max(best[p.doc], score)
+			}
+		}
+		for doc, score := range best {
+			scores[doc] += score
+		}
+	}
+
+	hits := make([]int, 0, len(scores))
+	for i := range scores {
+		hits = append(hits, i)
+	}
+	slices.SortFunc(hits, func(a, b int) int {
+		if scores[a] != scores[b] {
+			if scores[a] > scores[b] {
+				return -1
+			}
+			return 1
+		}
+		return strings.Compare(idx.docs[a].doc.Title, idx.docs[b].doc.Title)
+	})
+	if limit > 0 && len(hits) > limit {
+		hits = hits[:limit]
+	}
+
+	results := make([]Result, len(hits))
+	for i, hit := range hits {
+		results[i] = Result{
+			Document: idx.docs[hit].doc,
+			Score:    scores[hit],
+			Snippet:  snippet(idx.docs[hit].body, matched),
+		}
+	}
+
+	return results
+}
+
+var (
+	fencedCode    = regexp.MustCompile("(?m)^(```|~~~).*$")
+	markdownImage = regexp.MustCompile(`!\[([^\]]*)\]\([^)]*\)`)
+	markdownLink  = regexp.MustCompile(`\[([^\]]*)\]\([^)]*\)`)
+	htmlTag       = regexp.MustCompile(`<[^>]+>`)
+	markdownMarks = regexp.MustCompile("(?m)^\\s{0,3}(#{1,6}|>|[-*+]|\\d+\\.)\\s+|[*_`~|]")
+	whitespace    = regexp.MustCompile(`\s+`)
+)
+
+// plainText strips markdown syntax, keeping the words of prose and code
+func plainText(markdown string) string {
+	text := fencedCode.ReplaceAllString(markdown, " ")
+	text = markdownImage.ReplaceAllString(text, "$1")
+	text = markdownLink.ReplaceAllString(text, "$1")
+	text = htmlTag.ReplaceAllString(text, " ")
+	text = markdownMarks.ReplaceAllString(text, " ")
+	return strings.TrimSpace(whitespace.ReplaceAllString(text, " "))
+}
@@ -0,0 +1,115 @@
+package search
+
+import (
+	"encoding/base64"
+	"slices"
+	"strings"
+	"testing"
+
+	"markitos-it-app-website/internal/domain/documents"
+)
+
+func testDocument(id, title, body string) documents.Document {
+	return documents.Document{
+		ID:         id,
+		Title:      title,
+		Category:   "Test",
+		UpdatedAt:  "2025-01-01",
+		ContentB64: base64.StdEncoding.EncodeToString([]byte(body)),
+	}
+}
+
+// filler is prose that matches none of the test queries
+var filler = strings.Repeat("Latency budgets shape every design review we run. ", 20)
+
+func resultIDs(results []Result) []string {
+	ids := []string{}
+	for _, r := range results {
+		ids = append(ids, r.Document.ID)
+	}
+	return ids
+}
+
+func TestIndexSearchRanking(t *testing.T) {
+	idx := NewIndex([]documents.Document{
+		testDocument("incidental", "Caching layers", filler+"Kubernetes is mentioned once here. "+filler),
+		testDocument("body", "Cluster operations", "Kubernetes pods, Kubernetes services and Kubernetes ingress."),
+		testDocument("title", "Kubernetes in production", filler),
+		testDocument("unrelated", "Observability", filler),
+		testDocument("spanish", "Despliegues", "La configuración de los servidores y las políticas de red."),
+	})
+
+	tests := []struct {
+		name  string
+		query string
+		want  []string
+	}{
+		{
+			name:  "focused body and title outrank an incidental mention",
+			query: "kubernetes",
+			want:  []string{"body", "title", "incidental"},
+		},
+		{
+			name:  "stemmed match",
+			query: "clusters",
+			want:  []string{"body"},
+		},
+		{
+			name:  "query without accents",
+			query: "configuracion",
+			want:  []string{"spanish"},
+		},
+		{
+			name:  "spanish plural",
+			query: "politicas",
+			want:  []string{"spanish"},
+		},
+		{
+			name:  "no match",
+			query: "terraform",
+			want:  []string{},
+		},
+		{
+			name:  "empty query",
+			query: "  ",
+			want:  []string{},
+		},
+		{
+			name:  "stopwords only",
+			query: "the of de la",
+			want:  []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := resultIDs(idx.Search(tt.query, 10)); !slices.Equal(got, tt.want) {
+				t.Errorf("Search(%q) = %v, want %v", tt.query, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIndexSearchLimitAndSummaries(t *testing.T) {
+	idx := NewIndex([]documents.Document{
+		testDocument("a", "Kubernetes A", "kubernetes"),
+		testDocument("b", "Kubernetes B", "kubernetes"),
+		testDocument("c", "Kubernetes C", "kubernetes"),
+	})
+
+	results := idx.Search("kubernetes", 2)
+	if got := resultIDs(results); !slices.Equal(got, []string{"a", "b"}) {
+		t.Errorf("Search with limit 2 = %v, want [a b] (ties sorted by title)", got)
+	}
+	for _, r := range results {
+		if r.Document.ContentB64 != "" {
+			t.Errorf("result %s kept its content", r.Document.ID)
+		}
+	}
+}
+
+func TestSearchEmptyIndex(t *testing.T) {
+	if got := NewIndex(nil).Search("kubernetes", 10); got != nil {
+		t.Errorf("Search on an empty index = %v, want nil", got)
+	}
+}
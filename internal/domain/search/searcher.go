@@ -0,0 +1,97 @@
+package search
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"log"
+	"log/slog"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"markitos-it-app-website/internal/domain/documents"
+)
+
+// Searcher keeps a full-text index of a document repository up to date
+type Searcher struct {
+	repo documents.Repository
+
+	index       atomic.Pointer[Index]
+	mu          sync.Mutex
+	fingerprint string
+}
+
+// NewSearcher creates a searcher over repo. The index is built on the first
+// call to Rebuild or Search.
+func NewSearcher(repo documents.Repository) *Searcher {
+	return &Searcher{repo: repo}
+}
+
+// Search runs query against the current index, building it first if needed
+func (s *Searcher) Search(ctx context.Context, query string, limit int) ([]Result, error) {
+	idx := s.index.Load()
+	if idx == nil {
+		if err := s.Rebuild(ctx); err != nil {
+			return nil, err
+		}
+		idx = s.index.Load()
+	}
+	return idx.Search(query, limit), nil
+}
+
+// Rebuild re-indexes the repository if its documents changed since the last build
+func (s *Searcher) Rebuild(ctx context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	docs, err := s.repo.List(ctx)
+	if err != nil {
+		return err
+	}
+
+	fingerprint := fingerprintOf(docs)
+	if s.index.Load() != nil && fingerprint == s.fingerprint {
+		return nil
+	}
+
+	start := time.Now()
+	idx := NewIndex(docs)
+	s.index.Store(idx)
+	s.fingerprint = fingerprint
+
+	log.Printf("🔎 Search index built with %d documents in %s", idx.Len(), time.Since(start).Round(time.Millisecond))
+
+	return nil
+}
+
+// Watch rebuilds the index every interval until ctx is cancelled, so changes
+// in a remote source are picked up without a restart
+func (s *Searcher) Watch(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := s.Rebuild(ctx); err != nil {
+				slog.Warn("Search index rebuild failed", "err", err)
+			}
+		}
+	}
+}
+
+// fingerprintOf hashes the IDs, metadata and content of a document set
+func fingerprintOf(docs []documents.Document) string {
+	h := sha256.New()
+	for _, doc := range docs {
+		for _, field := range []string{doc.ID, doc.UpdatedAt, doc.Title, doc.Description, doc.Category, strings.Join(doc.Tags, ","), doc.ContentB64} {
+			h.Write([]byte(field))
+			h.Write([]byte{0})
+		}
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
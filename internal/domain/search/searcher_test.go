@@ -0,0 +1,75 @@
+package search
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"testing"
+
+	"markitos-it-app-website/internal/domain/documents"
+)
+
+// memoryRepository serves a document set that tests can replace
+type memoryRepository struct {
+	mu   sync.Mutex
+	docs []documents.Document
+}
+
+func (r *memoryRepository) set(docs ...documents.Document) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.docs = docs
+}
+
+func (r *memoryRepository) List(context.Context) ([]documents.Document, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.docs, nil
+}
+
+func (r *memoryRepository) ListSummaries(ctx context.Context, _ documents.Query) ([]documents.Document, error) {
+	return r.List(ctx)
+}
+
+func (r *memoryRepository) Get(context.Context, string) (*documents.Document, error) {
+	return nil, documents.ErrNotFound
+}
+
+func TestSearcherRebuild(t *testing.T) {
+	ctx := context.Background()
+	repo := &memoryRepository{}
+	repo.set(testDocument("k8s", "Kubernetes", "Pods and services"))
+	searcher := NewSearcher(repo)
+
+	search := func(query string) []string {
+		t.Helper()
+		results, err := searcher.Search(ctx, query, 10)
+		if err != nil {
+			t.Fatalf("Search(%q) error = %v", query, err)
+		}
+		return resultIDs(results)
+	}
+
+	if got := search("pods"); !slices.Equal(got, []string{"k8s"}) {
+		t.Fatalf("first Search builds the index: got %v, want [k8s]", got)
+	}
+
+	built := searcher.index.Load()
+	if err := searcher.Rebuild(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if searcher.index.Load() != built {
+		t.Error("Rebuild replaced the index although the documents did not change")
+	}
+
+	repo.set(testDocument("cdn", "CDN", "Edge caching"))
+	if err := searcher.Rebuild(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if got := search("pods"); len(got) != 0 {
+		t.Errorf("removed document still found: %v", got)
+	}
+	if got := search("caching"); !slices.Equal(got, []string{"cdn"}) {
+		t.Errorf("Search after Rebuild = %v, want [cdn]", got)
+	}
+}
@@ -0,0 +1,75 @@
+package search
+
+import (
+	"html"
+	"html/template"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	snippetLength = 200
+	snippetLead   = 60
+)
+
+// snippet returns an excerpt of body around its first matched word, with
+// every matched word wrapped in <mark>. Falls back to the start of the body.
+func snippet(body string, matched map[string]bool) template.HTML {
+	tokens := tokenize(body)
+
+	isMatch := func(t token) bool {
+		return !isStopword(t.word) && (matched[stem(t.word, English)] || matched[stem(t.word, Spanish)])
+	}
+
+	start := 0
+	for _, t := range tokens {
+		if isMatch(t) {
+			start = t.start - snippetLead
+			break
+		}
+	}
+	start = max(start, 0)
+	end := min(start+snippetLength, len(body))
+
+	// The window is measured in bytes; never cut an accented letter in two
+	for start > 0 && !utf8.RuneStart(body[start]) {
+		start--
+	}
+	for end < len(body) && !utf8.RuneStart(body[end]) {
+		end--
+	}
+
+	// Move the window edges to word boundaries
+	if start > 0 {
+		if i := strings.IndexByte(body[start:end], ' '); i >= 0 {
+			start += i + 1
+		}
+	}
+	if end < len(body) {
+		if i := strings.LastIndexByte(body[start:end], ' '); i > 0 {
+			end = start + i
+		}
+	}
+
+	var b strings.Builder
+	if start > 0 {
+		b.WriteString("… ")
+	}
+	pos := start
+	for _, t := range tokens {
+		if t.start < start || t.end > end || !isMatch(t) {
+			continue
+		}
+		b.WriteString(html.EscapeString(body[pos:t.start]))
+		b.WriteString("<mark>")
+		b.WriteString(html.EscapeString(body[t.start:t.end]))
+		b.WriteString("</mark>")
+		pos = t.end
+	}
+	b.WriteString(html.EscapeString(body[pos:end]))
+	if end < len(body) {
+		b.WriteString(" …")
+	}
+
+	return template.HTML(b.String())
+}
@@ -0,0 +1,63 @@
+package search
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestSnippet(t *testing.T) {
+	tests := []struct {
+		name    string
+		body    string
+		matched map[string]bool
+		want    string
+	}{
+		{
+			name:    "marks every match",
+			body:    "Deploy pods. Scale pods.",
+			matched: map[string]bool{"pod": true},
+			want:    "Deploy <mark>pods</mark>. Scale <mark>pods</mark>.",
+		},
+		{
+			name:    "escapes the body",
+			body:    "Use <b> & pods",
+			matched: map[string]bool{"pod": true},
+			want:    "Use &lt;b&gt; &amp; <mark>pods</mark>",
+		},
+		{
+			name:    "accented match",
+			body:    "Revisa la configuración antes",
+			matched: map[string]bool{"configuracion": true},
+			want:    "Revisa la <mark>configuración</mark> antes",
+		},
+		{
+			name: "no match starts at the beginning",
+			body: "Nothing to see",
+			want: "Nothing to see",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := string(snippet(tt.body, tt.matched)); got != tt.want {
+				t.Errorf("snippet = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSnippetKeepsMultibyteRunesWhole(t *testing.T) {
+	// No spaces near the window edges, so they cannot snap to word boundaries
+	for pad := range 4 {
+		body := strings.Repeat("ñ", 100+pad) + ",configuración," + strings.Repeat("é", 150)
+		got := string(snippet(body, map[string]bool{"configuracion": true}))
+
+		if !utf8.ValidString(got) {
+			t.Errorf("pad %d: snippet is not valid UTF-8: %q", pad, got)
+		}
+		if !strings.Contains(got, "<mark>configuración</mark>") {
+			t.Errorf("pad %d: snippet %q does not mark the match", pad, got)
+		}
+	}
+}
@@ -0,0 +1,27 @@
+package feed
+
+import (
+	"time"
+)
+
+// Feed is a list of articles that can be written as RSS, Atom or JSON Feed
+type Feed struct {
+	Title       string
+	Description string
+	// Link is the page the feed is about and Self the URL of the feed itself
+	Link    string
+	Self    string
+	Updated time.Time
+	Items   []Item
+}
+
+// Item is an article of a feed
+type Item struct {
+	Title       string
+	Link        string
+	Summary     string
+	ContentHTML string
+	Image       string
+	Categories  []string
+	Updated     time.Time
+}
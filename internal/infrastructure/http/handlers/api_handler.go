@@ -0,0 +1,277 @@
+package handlers
+
+import (
+	_ "embed"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"markitos-it-app-website/internal/domain/documents"
+	"markitos-it-app-website/internal/infrastructure/markdown"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+//go:embed openapi.json
+var openAPISpec []byte
+
+// APIHandler serves the JSON API under /api/v1, the same documents, filters
+// and pagination as the HTML pages
+type APIHandler struct {
+	repo     documents.Repository
+	markdown *markdown.Renderer
+	siteURL  string
+}
+
+// NewAPIHandler creates the API handler; siteURL is used for absolute links
+func NewAPIHandler(repo documents.Repository, renderer *markdown.Renderer, siteURL string) *APIHandler {
+	return &APIHandler{repo: repo, markdown: renderer, siteURL: strings.TrimRight(siteURL, "/")}
+}
+
+type apiDocument struct {
+	ID          string         `json:"id"`
+	Title       string         `json:"title"`
+	Description string         `json:"description"`
+	Category    string         `json:"category"`
+	Tags        []string       `json:"tags"`
+	UpdatedAt   string         `json:"updated_at"`
+	CoverImage  string         `json:"cover_image,omitempty"`
+	URL         string         `json:"url"`
+	Stats       apiStats       `json:"stats"`
+	Markdown    string         `json:"markdown,omitempty"`
+	HTML        string         `json:"html,omitempty"`
+	TOC         []*apiTOCEntry `json:"toc,omitempty"`
+}
+
+type apiStats struct {
+	Words          int `json:"words"`
+	ReadingMinutes int `json:"reading_minutes"`
+	Sections       int `json:"sections"`
+	CodeBlocks     int `json:"code_blocks"`
+}
+
+type apiTOCEntry struct {
+	ID       string         `json:"id"`
+	Title    string         `json:"title"`
+	Level    int            `json:"level"`
+	Children []*apiTOCEntry `json:"children,omitempty"`
+}
+
+type apiPagination struct {
+	Page       int    `json:"page"`
+	PerPage    int    `json:"per_page"`
+	Total      int    `json:"total"`
+	TotalPages int    `json:"total_pages"`
+	Prev       string `json:"prev,omitempty"`
+	Next       string `json:"next,omitempty"`
+}
+
+type apiCount struct {
+	Name  string `json:"name"`
+	Count int    `json:"count"`
+}
+
+type apiError struct {
+	Status  int    `json:"status"`
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// Docs serves /api/v1/docs, accepting the query parameters of /docs/
+func (h *APIHandler) Docs(w http.ResponseWriter, r *http.Request) {
+	query := parseDocsQuery(r.URL.Query())
+	docs, ok := h.list(w, r, query.Filter())
+	if !ok {
+		return
+	}
+
+	page := documents.ApplyQuery(docs, query)
+
+	data := make([]apiDocument, len(page.Documents))
+	for i, doc := range page.Documents {
+		data[i] = h.document(doc)
+	}
+
+	pagination := apiPagination{
+		Page:       page.Page,
+		PerPage:    page.PerPage,
+		Total:      page.Total,
+		TotalPages: page.TotalPages,
+	}
+	if page.HasPrev() {
+		pagination.Prev = apiDocsURL(withPage(query, page.Page-1))
+	}
+	if page.HasNext() {
+		pagination.Next = apiDocsURL(withPage(query, page.Page+1))
+	}
+
+	writeJSON(w, http.StatusOK, map[string]any{"data": data, "pagination": pagination})
+}
+
+// Doc serves /api/v1/docs/{id} with the raw markdown. ?render=true adds the
+// rendered HTML and the table of contents.
+func (h *APIHandler) Doc(w http.ResponseWriter, r *http.Request) {
+	doc, err := h.repo.Get(r.Context(), r.PathValue("id"))
+	switch {
+	case errors.Is(err, documents.ErrNotFound):
+		writeAPIError(w, http.StatusNotFound, "not_found", "Document not found")
+		return
+	case errors.Is(err, documents.ErrInvalidID):
+		writeAPIError(w, http.StatusBadRequest, "invalid_id", "Invalid document ID")
+		return
+	case errors.Is(err, documents.ErrUnavailable):
+		writeAPIError(w, http.StatusServiceUnavailable, "unavailable", "Documents service unavailable")
+		return
+	case err != nil:
+		writeAPIError(w, http.StatusInternalServerError, "internal", "Error loading document")
+		return
+	}
+
+	render := false
+	if value := r.URL.Query().Get("render"); value != "" {
+		render, err = strconv.ParseBool(value)
+		if err != nil {
+			writeAPIError(w, http.StatusBadRequest, "invalid_parameter", "render must be true or false")
+			return
+		}
+	}
+
+	content, err := base64.StdEncoding.DecodeString(doc.ContentB64)
+	if err != nil {
+		writeAPIError(w, http.StatusInternalServerError, "internal", "Error decoding document")
+		return
+	}
+
+	data := h.document(*doc)
+	data.Markdown = string(content)
+	if render {
+		rendered, err := h.markdown.Render(content)
+		if err != nil {
+			writeAPIError(w, http.StatusInternalServerError, "internal", "Error converting markdown")
+			return
+		}
+		data.HTML = string(rendered.HTML)
+		data.TOC = apiTOC(rendered.TOC)
+	}
+
+	writeJSON(w, http.StatusOK, map[string]any{"data": data})
+}
+
+// Categories serves /api/v1/categories with the number of documents in each
+func (h *APIHandler) Categories(w http.ResponseWriter, r *http.Request) {
+	docs, ok := h.list(w, r, documents.Query{})
+	if !ok {
+		return
+	}
+
+	data := []apiCount{}
+	for _, category := range documents.Categories(docs) {
+		page := documents.ApplyQuery(docs, documents.Query{Category: category})
+		data = append(data, apiCount{Name: category, Count: page.Total})
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"data": data})
+}
+
+// Tags serves /api/v1/tags with the number of documents tagged with each
+func (h *APIHandler) Tags(w http.ResponseWriter, r *http.Request) {
+	docs, ok := h.list(w, r, documents.Query{})
+	if !ok {
+		return
+	}
+
+	data := []apiCount{}
+	for _, tag := range documents.Tags(docs) {
+		page := documents.ApplyQuery(docs, documents.Query{Tag: tag})
+		data = append(data, apiCount{Name: tag, Count: page.Total})
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"data": data})
+}
+
+// OpenAPI serves /api/v1/openapi.json
+func (h *APIHandler) OpenAPI(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "public, max-age=3600")
+	w.Write(openAPISpec)
+}
+
+// NotFound answers unknown /api/ paths with the error envelope instead of the home page
+func (h *APIHandler) NotFound(w http.ResponseWriter, r *http.Request) {
+	writeAPIError(w, http.StatusNotFound, "not_found", "Unknown API endpoint")
+}
+
+func (h *APIHandler) list(w http.ResponseWriter, r *http.Request, filter documents.Query) ([]documents.Document, bool) {
+	docs, err := h.repo.ListSummaries(r.Context(), filter)
+	if errors.Is(err, documents.ErrUnavailable) {
+		writeAPIError(w, http.StatusServiceUnavailable, "unavailable", "Documents service unavailable")
+		return nil, false
+	}
+	if err != nil {
+		writeAPIError(w, http.StatusInternalServerError, "internal", "Error loading documents")
+		return nil, false
+	}
+	return docs, true
+}
+
+func (h *APIHandler) document(doc documents.Document) apiDocument {
+	tags := doc.Tags
+	if tags == nil {
+		tags = []string{}
+	}
+	return apiDocument{
+		ID:          doc.ID,
+		Title:       doc.Title,
+		Description: doc.Description,
+		Category:    doc.Category,
+		Tags:        tags,
+		UpdatedAt:   doc.UpdatedAt,
+		CoverImage:  doc.CoverImage,
+		URL:         h.siteURL + "/docs/" + url.PathEscape(doc.ID),
+		Stats: apiStats{
+			Words:          doc.Stats.Words,
+			ReadingMinutes: doc.Stats.ReadingMinutes,
+			Sections:       doc.Stats.Sections,
+			CodeBlocks:     doc.Stats.CodeBlocks,
+		},
+	}
+}
+
+func apiTOC(entries []*markdown.TOCEntry) []*apiTOCEntry {
+	out := make([]*apiTOCEntry, len(entries))
+	for i, entry := range entries {
+		out[i] = &apiTOCEntry{
+			ID:       entry.ID,
+			Title:    entry.Title,
+			Level:    entry.Level,
+			Children: apiTOC(entry.Children),
+		}
+	}
+	return out
+}
+
+// apiDocsURL returns the /api/v1/docs link for q
+func apiDocsURL(q documents.Query) string {
+	if encoded := docsQueryValues(q).Encode(); encoded != "" {
+		return "/api/v1/docs?" + encoded
+	}
+	return "/api/v1/docs"
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	body, err := json.Marshal(v)
+	if err != nil {
+		slog.Warn("Failed to encode API response", "err", err)
+		status = http.StatusInternalServerError
+		body = []byte(`{"error":{"status":500,"code":"internal","message":"Error encoding response"}}`)
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	w.Write(append(body, '\n'))
+}
+
+// writeAPIError writes the error envelope every API endpoint uses:
+// {"error": {"status": 404, "code": "not_found", "message": "..."}}
+func writeAPIError(w http.ResponseWriter, status int, code, message string) {
+	writeJSON(w, status, map[string]apiError{"error": {Status: status, Code: code, Message: message}})
+}
@@ -0,0 +1,107 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"markitos-it-app-website/internal/domain/documents"
+	"markitos-it-app-website/internal/infrastructure/markdown"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func serveAPI(repo documents.Repository, path string) *httptest.ResponseRecorder {
+	h := NewAPIHandler(repo, markdown.NewRenderer(markdown.DefaultOptions()), "https://example.com")
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/", h.NotFound)
+	mux.HandleFunc("/api/v1/docs", h.Docs)
+	mux.HandleFunc("/api/v1/docs/{id}", h.Doc)
+	mux.HandleFunc("/api/v1/categories", h.Categories)
+	mux.HandleFunc("/api/v1/tags", h.Tags)
+
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+	return rec
+}
+
+func TestAPIHandlerErrors(t *testing.T) {
+	docs := stubRepository{docs: testDocuments()}
+	unavailable := stubRepository{err: fmt.Errorf("%w: connection refused", documents.ErrUnavailable)}
+	broken := stubRepository{err: errors.New("boom")}
+
+	tests := []struct {
+		name     string
+		repo     documents.Repository
+		path     string
+		wantCode int
+		wantErr  string
+	}{
+		{name: "document not found", repo: docs, path: "/api/v1/docs/missing", wantCode: http.StatusNotFound, wantErr: "not_found"},
+		{name: "invalid document id", repo: stubRepository{err: documents.ErrInvalidID}, path: "/api/v1/docs/bad", wantCode: http.StatusBadRequest, wantErr: "invalid_id"},
+		{name: "document unavailable", repo: unavailable, path: "/api/v1/docs/k8s", wantCode: http.StatusServiceUnavailable, wantErr: "unavailable"},
+		{name: "document error", repo: broken, path: "/api/v1/docs/k8s", wantCode: http.StatusInternalServerError, wantErr: "internal"},
+		{name: "invalid render", repo: docs, path: "/api/v1/docs/k8s?render=maybe", wantCode: http.StatusBadRequest, wantErr: "invalid_parameter"},
+		{name: "docs unavailable", repo: unavailable, path: "/api/v1/docs", wantCode: http.StatusServiceUnavailable, wantErr: "unavailable"},
+		{name: "docs error", repo: broken, path: "/api/v1/docs", wantCode: http.StatusInternalServerError, wantErr: "internal"},
+		{name: "categories unavailable", repo: unavailable, path: "/api/v1/categories", wantCode: http.StatusServiceUnavailable, wantErr: "unavailable"},
+		{name: "tags error", repo: broken, path: "/api/v1/tags", wantCode: http.StatusInternalServerError, wantErr: "internal"},
+		{name: "unknown endpoint", repo: docs, path: "/api/v2/docs", wantCode: http.StatusNotFound, wantErr: "not_found"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := serveAPI(tt.repo, tt.path)
+
+			if rec.Code != tt.wantCode {
+				t.Errorf("GET %s = %d, want %d", tt.path, rec.Code, tt.wantCode)
+			}
+			if got := rec.Header().Get("Content-Type"); got != "application/json" {
+				t.Errorf("Content-Type = %q, want application/json", got)
+			}
+
+			var envelope struct {
+				Error *apiError `json:"error"`
+			}
+			if err := json.Unmarshal(rec.Body.Bytes(), &envelope); err != nil {
+				t.Fatalf("body %q is not JSON: %v", rec.Body.String(), err)
+			}
+			if envelope.Error == nil {
+				t.Fatalf("body %q has no error envelope", rec.Body.String())
+			}
+			if envelope.Error.Status != tt.wantCode || envelope.Error.Code != tt.wantErr || envelope.Error.Message == "" {
+				t.Errorf("error = %+v, want status %d, code %q and a message", *envelope.Error, tt.wantCode, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestAPIHandlerDocs(t *testing.T) {
+	rec := serveAPI(stubRepository{docs: testDocuments()}, "/api/v1/docs?per_page=1&page=2&sort=title")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("GET /api/v1/docs = %d: %s", rec.Code, rec.Body.String())
+	}
+
+	var body struct {
+		Data       []apiDocument `json:"data"`
+		Pagination apiPagination `json:"pagination"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(body.Data) != 1 || body.Data[0].ID != "k8s" {
+		t.Errorf("data = %+v, want the second document by title", body.Data)
+	}
+	want := apiPagination{
+		Page:       2,
+		PerPage:    1,
+		Total:      2,
+		TotalPages: 2,
+		Prev:       "/api/v1/docs?per_page=1&sort=title",
+	}
+	if body.Pagination != want {
+		t.Errorf("pagination = %+v, want %+v", body.Pagination, want)
+	}
+}
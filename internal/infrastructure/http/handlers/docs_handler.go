@@ -2,26 +2,25 @@ package handlers
 
 import (
 	"encoding/base64"
+	"errors"
 	"html/template"
 	"io"
 	"markitos-it-app-website/internal/domain/documents"
+	"markitos-it-app-website/internal/infrastructure/markdown"
 	"markitos-it-app-website/internal/templates"
 	"net/http"
 	"strings"
-
-	"github.com/yuin/goldmark"
-	"github.com/yuin/goldmark/extension"
-	"github.com/yuin/goldmark/parser"
-	"github.com/yuin/goldmark/renderer/html"
 )
 
 type DocsHandler struct {
+	repo      documents.Repository
 	indexTmpl *template.Template
 	viewTmpl  *template.Template
-	markdown  goldmark.Markdown
+	markdown  *markdown.Renderer
+	siteURL   string
 }
 
-func NewDocsHandler() (*DocsHandler, error) {
+func NewDocsHandler(repo documents.Repository, renderer *markdown.Renderer, siteURL string) (*DocsHandler, error) {
 	indexTmpl, err := template.New("base.html").ParseFS(
 		templates.FS(),
 		"shared/base.html",
@@ -56,27 +55,12 @@ func NewDocsHandler() (*DocsHandler, error) {
 		return nil, err
 	}
 
-	md := goldmark.New(
-		goldmark.WithExtensions(
-			extension.GFM,
-			extension.Table,
-			extension.Strikethrough,
-			extension.TaskList,
-		),
-		goldmark.WithParserOptions(
-			parser.WithAutoHeadingID(),
-		),
-		goldmark.WithRendererOptions(
-			html.WithHardWraps(),
-			html.WithXHTML(),
-			html.WithUnsafe(), // allow raw HTML (iframe) so the YouTube player renders
-		),
-	)
-
 	return &DocsHandler{
+		repo:      repo,
 		indexTmpl: indexTmpl,
 		viewTmpl:  viewTmpl,
-		markdown:  md,
+		markdown:  renderer,
+		siteURL:   siteURL,
 	}, nil
 }
 
@@ -97,41 +81,89 @@ func (h *DocsHandler) Index(w http.ResponseWriter, r *http.Request) {
 	pageJSBytes, _ := io.ReadAll(pageJS)
 	pageJS.Close()
 
-	docs, err := documents.GetAllDocuments()
+	// Every category is listed in the sidebar, so the filters are applied here
+	docs, err := h.repo.ListSummaries(r.Context(), documents.Query{})
+	if errors.Is(err, documents.ErrUnavailable) {
+		http.Error(w, "Documents service unavailable", http.StatusServiceUnavailable)
+		return
+	}
 	if err != nil {
 		http.Error(w, "Error loading documents", http.StatusInternalServerError)
 		return
 	}
 
-	categories := []string{"All"}
-	categoryMap := make(map[string]bool)
-	for _, doc := range docs {
-		if !categoryMap[doc.Category] {
-			categories = append(categories, doc.Category)
-			categoryMap[doc.Category] = true
-		}
+	query := parseDocsQuery(r.URL.Query())
+	page := documents.ApplyQuery(docs, query)
+
+	categories := []map[string]interface{}{
+		{"Name": "All", "URL": docsIndexURL(withCategory(query, "")), "Active": query.Category == ""},
 	}
+	for _, category := range documents.Categories(docs) {
+		categories = append(categories, map[string]interface{}{
+			"Name":   category,
+			"URL":    docsIndexURL(withCategory(query, category)),
+			"Active": strings.EqualFold(query.Category, category),
+		})
+	}
+
+	docsInterface := make([]map[string]interface{}, len(page.Documents))
+	for i, doc := range page.Documents {
+		tagLinks := make([]map[string]string, len(doc.Tags))
+		for j, tag := range doc.Tags {
+			tagLinks[j] = map[string]string{"Name": tag, "URL": docsIndexURL(withTag(query, tag))}
+		}
 
-	docsInterface := make([]map[string]interface{}, len(docs))
-	for i, doc := range docs {
 		docsInterface[i] = map[string]interface{}{
 			"ID":          doc.ID,
 			"Title":       doc.Title,
 			"Description": doc.Description,
 			"Category":    doc.Category,
-			"Tags":        doc.Tags,
+			"Tags":        tagLinks,
 			"UpdatedAt":   doc.UpdatedAt,
-			"ContentB64":  doc.ContentB64,
 			"CoverImage":  doc.CoverImage,
+			"Stats":       doc.Stats,
 		}
 	}
 
+	pages := make([]map[string]interface{}, page.TotalPages)
+	for i := range pages {
+		pages[i] = map[string]interface{}{
+			"Number":  i + 1,
+			"URL":     docsIndexURL(withPage(query, i+1)),
+			"Current": i+1 == page.Page,
+		}
+	}
+	pagination := map[string]interface{}{
+		"Total":      page.Total,
+		"Page":       page.Page,
+		"TotalPages": page.TotalPages,
+		"HasPrev":    page.HasPrev(),
+		"HasNext":    page.HasNext(),
+		"PrevURL":    docsIndexURL(withPage(query, page.Page-1)),
+		"NextURL":    docsIndexURL(withPage(query, page.Page+1)),
+		"Pages":      pages,
+	}
+
+	feedURL := feedPath("", rssFormat)
+	if category, ok := findCategory(docs, query.Category); ok {
+		feedURL = feedPath(category, rssFormat)
+	}
+
+	// Search, tag and sort variants all point at the plain category page
+	canonicalQuery := documents.Query{Category: query.Category, Page: query.Page}
+
 	data := map[string]interface{}{
 		"PageClass":     "docs-page",
 		"Title":         "Documentation Dashboard",
+		"Meta":          websiteMeta(h.siteURL, docsIndexURL(canonicalQuery), "Documentation Dashboard", "Browse MarkitosIT guides on DevOps, cloud infrastructure and software architecture"),
 		"ActiveSection": "docs",
 		"Documents":     docsInterface,
 		"Categories":    categories,
+		"Query":         query,
+		"ClearTagURL":   docsIndexURL(withTag(query, "")),
+		"FeedURL":       feedURL,
+		"PerPageParam":  docsQueryValues(query).Get("per_page"),
+		"Pagination":    pagination,
 		"SharedStyles":  template.CSS(string(sharedCSSBytes)),
 		"SharedScript":  template.JS(string(sharedJSBytes)),
 		"PageStyles":    template.CSS(string(pageCSSBytes)),
@@ -147,15 +179,20 @@ func (h *DocsHandler) View(w http.ResponseWriter, r *http.Request) {
 	path := r.URL.Path
 	docID := strings.TrimPrefix(path, "/docs/")
 
-	doc, err := documents.GetDocumentById(docID)
-	if err != nil {
-		http.Error(w, "Error loading document", http.StatusInternalServerError)
-		return
-	}
-
-	if doc == nil {
+	doc, err := h.repo.Get(r.Context(), docID)
+	switch {
+	case errors.Is(err, documents.ErrNotFound):
 		http.NotFound(w, r)
 		return
+	case errors.Is(err, documents.ErrInvalidID):
+		http.Error(w, "Invalid document ID", http.StatusBadRequest)
+		return
+	case errors.Is(err, documents.ErrUnavailable):
+		http.Error(w, "Documents service unavailable", http.StatusServiceUnavailable)
+		return
+	case err != nil:
+		http.Error(w, "Error loading document", http.StatusInternalServerError)
+		return
 	}
 
 	contentMarkdown, err := base64.StdEncoding.DecodeString(doc.ContentB64)
@@ -164,8 +201,8 @@ func (h *DocsHandler) View(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	var htmlContent strings.Builder
-	if err := h.markdown.Convert(contentMarkdown, &htmlContent); err != nil {
+	rendered, err := h.markdown.Render(contentMarkdown)
+	if err != nil {
 		http.Error(w, "Error converting markdown", http.StatusInternalServerError)
 		return
 	}
@@ -181,6 +218,7 @@ func (h *DocsHandler) View(w http.ResponseWriter, r *http.Request) {
 	pageCSS, _ := templates.FS().Open("docs/view/styles.css")
 	pageCSSBytes, _ := io.ReadAll(pageCSS)
 	pageCSS.Close()
+	pageCSSBytes = append(pageCSSBytes, "\n"+markdown.HighlightCSS()...)
 
 	pageJS, _ := templates.FS().Open("docs/view/script.js")
 	pageJSBytes, _ := io.ReadAll(pageJS)
@@ -189,6 +227,7 @@ func (h *DocsHandler) View(w http.ResponseWriter, r *http.Request) {
 	data := map[string]interface{}{
 		"PageClass":     "docs-view-page",
 		"Title":         doc.Title,
+		"Meta":          documentMeta(h.siteURL, doc),
 		"ActiveSection": "docs",
 		"ID":            doc.ID,
 		"Category":      doc.Category,
@@ -196,7 +235,9 @@ func (h *DocsHandler) View(w http.ResponseWriter, r *http.Request) {
 		"Tags":          doc.Tags,
 		"UpdatedAt":     doc.UpdatedAt,
 		"CoverImage":    doc.CoverImage,
-		"Content":       template.HTML(htmlContent.String()),
+		"Stats":         doc.Stats,
+		"Content":       rendered.HTML,
+		"TOC":           rendered.TOC,
 		"SharedStyles":  template.CSS(string(sharedCSSBytes)),
 		"SharedScript":  template.JS(string(sharedJSBytes)),
 		"PageStyles":    template.CSS(string(pageCSSBytes)),
@@ -0,0 +1,53 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"markitos-it-app-website/internal/domain/documents"
+	"markitos-it-app-website/internal/infrastructure/markdown"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestDocsHandlerErrors(t *testing.T) {
+	unavailable := fmt.Errorf("%w: connection refused", documents.ErrUnavailable)
+	invalid := fmt.Errorf("%w: ../secrets", documents.ErrInvalidID)
+
+	tests := []struct {
+		name     string
+		repo     stubRepository
+		index    bool
+		path     string
+		wantCode int
+	}{
+		{name: "view", repo: stubRepository{docs: testDocuments()}, path: "/docs/k8s", wantCode: http.StatusOK},
+		{name: "view not found", repo: stubRepository{docs: testDocuments()}, path: "/docs/missing", wantCode: http.StatusNotFound},
+		{name: "view invalid id", repo: stubRepository{err: invalid}, path: "/docs/..%2Fsecrets", wantCode: http.StatusBadRequest},
+		{name: "view unavailable", repo: stubRepository{err: unavailable}, path: "/docs/k8s", wantCode: http.StatusServiceUnavailable},
+		{name: "view other error", repo: stubRepository{err: errors.New("boom")}, path: "/docs/k8s", wantCode: http.StatusInternalServerError},
+		{name: "index", repo: stubRepository{docs: testDocuments()}, index: true, path: "/docs/", wantCode: http.StatusOK},
+		{name: "index unavailable", repo: stubRepository{err: unavailable}, index: true, path: "/docs/", wantCode: http.StatusServiceUnavailable},
+		{name: "index other error", repo: stubRepository{err: errors.New("boom")}, index: true, path: "/docs/", wantCode: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h, err := NewDocsHandler(tt.repo, markdown.NewRenderer(markdown.DefaultOptions()), "https://example.com")
+			if err != nil {
+				t.Fatalf("NewDocsHandler error = %v", err)
+			}
+
+			handle := h.View
+			if tt.index {
+				handle = h.Index
+			}
+			rec := httptest.NewRecorder()
+			handle(rec, httptest.NewRequest(http.MethodGet, tt.path, nil))
+
+			if rec.Code != tt.wantCode {
+				t.Errorf("GET %s = %d, want %d", tt.path, rec.Code, tt.wantCode)
+			}
+		})
+	}
+}
@@ -0,0 +1,78 @@
+package handlers
+
+import (
+	"markitos-it-app-website/internal/domain/documents"
+	"net/url"
+	"strconv"
+)
+
+// parseDocsQuery reads ?category=, ?tag=, ?q=, ?sort=, ?page= and ?per_page=
+// into a normalized query; invalid numbers fall back to the defaults
+func parseDocsQuery(values url.Values) documents.Query {
+	page, _ := strconv.Atoi(values.Get("page"))
+	perPage, _ := strconv.Atoi(values.Get("per_page"))
+
+	return documents.Query{
+		Category: values.Get("category"),
+		Tag:      values.Get("tag"),
+		Text:     values.Get("q"),
+		Sort:     documents.SortOrder(values.Get("sort")),
+		Page:     page,
+		PerPage:  perPage,
+	}.Normalize()
+}
+
+// docsQueryValues encodes q as query parameters, leaving out defaults so
+// links stay short and stable
+func docsQueryValues(q documents.Query) url.Values {
+	values := url.Values{}
+	if q.Category != "" {
+		values.Set("category", q.Category)
+	}
+	if q.Tag != "" {
+		values.Set("tag", q.Tag)
+	}
+	if q.Text != "" {
+		values.Set("q", q.Text)
+	}
+	if q.Sort != "" && q.Sort != documents.SortUpdated {
+		values.Set("sort", string(q.Sort))
+	}
+	if q.Page > 1 {
+		values.Set("page", strconv.Itoa(q.Page))
+	}
+	if q.PerPage > 0 && q.PerPage != documents.DefaultPerPage {
+		values.Set("per_page", strconv.Itoa(q.PerPage))
+	}
+	return values
+}
+
+// docsIndexURL returns the /docs link that renders q
+func docsIndexURL(q documents.Query) string {
+	if encoded := docsQueryValues(q).Encode(); encoded != "" {
+		return "/docs/?" + encoded
+	}
+	return "/docs/"
+}
+
+// withCategory returns q filtered by category instead, back on the first
+// page; "" clears the filter. Tag, search and sort are kept.
+func withCategory(q documents.Query, category string) documents.Query {
+	q.Category = category
+	q.Page = 1
+	return q
+}
+
+// withTag returns q filtered by tag instead, back on the first page; ""
+// clears the filter. Category, search and sort are kept.
+func withTag(q documents.Query, tag string) documents.Query {
+	q.Tag = tag
+	q.Page = 1
+	return q
+}
+
+// withPage returns q on page n, keeping every filter
+func withPage(q documents.Query, n int) documents.Query {
+	q.Page = n
+	return q
+}
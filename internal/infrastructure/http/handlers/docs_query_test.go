@@ -0,0 +1,135 @@
+package handlers
+
+import (
+	"markitos-it-app-website/internal/domain/documents"
+	"net/url"
+	"testing"
+)
+
+// roundTrip parses the query of a generated link back, as the next request would
+func roundTrip(t *testing.T, link string) documents.Query {
+	t.Helper()
+	u, err := url.Parse(link)
+	if err != nil {
+		t.Fatalf("url.Parse(%q) error = %v", link, err)
+	}
+	if u.Path != "/docs/" {
+		t.Errorf("link %q points at %q, want /docs/", link, u.Path)
+	}
+	return parseDocsQuery(u.Query())
+}
+
+func TestParseDocsQuery(t *testing.T) {
+	tests := []struct {
+		name  string
+		query string
+		want  documents.Query
+	}{
+		{
+			name:  "defaults",
+			query: "",
+			want:  documents.Query{Sort: documents.SortUpdated, Page: 1, PerPage: documents.DefaultPerPage},
+		},
+		{
+			name:  "every parameter",
+			query: "category=Backend&tag=go&q=grpc+streams&sort=title&page=2&per_page=5",
+			want:  documents.Query{Category: "Backend", Tag: "go", Text: "grpc streams", Sort: documents.SortTitle, Page: 2, PerPage: 5},
+		},
+		{
+			name:  "invalid numbers fall back",
+			query: "page=two&per_page=-3&sort=newest",
+			want:  documents.Query{Sort: documents.SortUpdated, Page: 1, PerPage: documents.DefaultPerPage},
+		},
+		{
+			name:  "per page clamped",
+			query: "per_page=5000",
+			want:  documents.Query{Sort: documents.SortUpdated, Page: 1, PerPage: documents.MaxPerPage},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			values, err := url.ParseQuery(tt.query)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := parseDocsQuery(values); got != tt.want {
+				t.Errorf("parseDocsQuery(%q) = %+v, want %+v", tt.query, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDocsIndexURL(t *testing.T) {
+	tests := []struct {
+		query documents.Query
+		want  string
+	}{
+		{query: documents.Query{}, want: "/docs/"},
+		{query: documents.Query{Sort: documents.SortUpdated, Page: 1, PerPage: documents.DefaultPerPage}, want: "/docs/"},
+		{query: documents.Query{Category: "Cloud & DevOps", Page: 2}, want: "/docs/?category=Cloud+%26+DevOps&page=2"},
+		{query: documents.Query{Tag: "c++", Text: "a/b", Sort: documents.SortTitle, PerPage: 24}, want: "/docs/?per_page=24&q=a%2Fb&sort=title&tag=c%2B%2B"},
+	}
+
+	for _, tt := range tests {
+		if got := docsIndexURL(tt.query); got != tt.want {
+			t.Errorf("docsIndexURL(%+v) = %q, want %q", tt.query, got, tt.want)
+		}
+	}
+}
+
+func TestDocsIndexLinksRoundTrip(t *testing.T) {
+	current := parseDocsQuery(url.Values{
+		"category": {"Infrastructure"},
+		"tag":      {"c++"},
+		"q":        {"cache & proxy"},
+		"sort":     {"title"},
+		"page":     {"3"},
+		"per_page": {"24"},
+	})
+
+	tests := []struct {
+		name string
+		link string
+		want documents.Query
+	}{
+		{
+			name: "category link keeps tag, search and sort",
+			link: docsIndexURL(withCategory(current, "Cloud & DevOps")),
+			want: documents.Query{Category: "Cloud & DevOps", Tag: "c++", Text: "cache & proxy", Sort: documents.SortTitle, Page: 1, PerPage: 24},
+		},
+		{
+			name: "all categories link",
+			link: docsIndexURL(withCategory(current, "")),
+			want: documents.Query{Tag: "c++", Text: "cache & proxy", Sort: documents.SortTitle, Page: 1, PerPage: 24},
+		},
+		{
+			name: "tag link keeps category, search and sort",
+			link: docsIndexURL(withTag(current, "proxy")),
+			want: documents.Query{Category: "Infrastructure", Tag: "proxy", Text: "cache & proxy", Sort: documents.SortTitle, Page: 1, PerPage: 24},
+		},
+		{
+			name: "clear tag link",
+			link: docsIndexURL(withTag(current, "")),
+			want: documents.Query{Category: "Infrastructure", Text: "cache & proxy", Sort: documents.SortTitle, Page: 1, PerPage: 24},
+		},
+		{
+			name: "page link keeps the filters",
+			link: docsIndexURL(withPage(current, 4)),
+			want: documents.Query{Category: "Infrastructure", Tag: "c++", Text: "cache & proxy", Sort: documents.SortTitle, Page: 4, PerPage: 24},
+		},
+		{
+			name: "first page link",
+			link: docsIndexURL(withPage(current, 1)),
+			want: documents.Query{Category: "Infrastructure", Tag: "c++", Text: "cache & proxy", Sort: documents.SortTitle, Page: 1, PerPage: 24},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := roundTrip(t, tt.link); got != tt.want {
+				t.Errorf("%s parses back as %+v, want %+v", tt.link, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,281 @@
+package handlers
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"html"
+	"log/slog"
+	"markitos-it-app-website/internal/domain/documents"
+	"markitos-it-app-website/internal/infrastructure/feed"
+	"markitos-it-app-website/internal/infrastructure/markdown"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	feedSize  = 20
+	siteTitle = "MarkitosIT"
+
+	// maxCachedFeeds bounds the feed cache. Keys are canonical feed paths, so
+	// it only fills up with categories that have since been removed.
+	maxCachedFeeds = 64
+)
+
+type feedFormat struct {
+	// file is the name of the feed under /docs/ and under each category
+	file        string
+	contentType string
+	write       func(feed.Feed) ([]byte, error)
+}
+
+var (
+	rssFormat  = feedFormat{"feed.xml", "application/rss+xml; charset=utf-8", feed.Feed.RSS}
+	atomFormat = feedFormat{"atom.xml", "application/atom+xml; charset=utf-8", feed.Feed.Atom}
+	jsonFormat = feedFormat{"feed.json", "application/feed+json; charset=utf-8", feed.Feed.JSON}
+)
+
+// feedPath returns the canonical path of the feed of category, or of the
+// whole documentation when category is ""
+func feedPath(category string, format feedFormat) string {
+	if category == "" {
+		return "/docs/" + format.file
+	}
+	return "/docs/category/" + url.PathEscape(category) + "/" + format.file
+}
+
+// FeedHandler serves the RSS, Atom and JSON feeds of the documentation
+type FeedHandler struct {
+	repo     documents.Repository
+	markdown *markdown.Renderer
+	siteURL  string
+
+	mu sync.Mutex
+	// cache holds the last feed built for each canonical feed path
+	cache map[string]cachedFeed
+}
+
+type cachedFeed struct {
+	etag string
+	body []byte
+}
+
+// NewFeedHandler creates a feed handler; siteURL is used to build the
+// absolute links feed readers need
+func NewFeedHandler(repo documents.Repository, renderer *markdown.Renderer, siteURL string) *FeedHandler {
+	return &FeedHandler{
+		repo:     repo,
+		markdown: renderer,
+		siteURL:  strings.TrimRight(siteURL, "/"),
+		cache:    make(map[string]cachedFeed),
+	}
+}
+
+// RSS serves /docs/feed.xml
+func (h *FeedHandler) RSS(w http.ResponseWriter, r *http.Request) {
+	h.serve(w, r, "", rssFormat)
+}
+
+// Atom serves /docs/atom.xml
+func (h *FeedHandler) Atom(w http.ResponseWriter, r *http.Request) {
+	h.serve(w, r, "", atomFormat)
+}
+
+// JSON serves /docs/feed.json
+func (h *FeedHandler) JSON(w http.ResponseWriter, r *http.Request) {
+	h.serve(w, r, "", jsonFormat)
+}
+
+// CategoryRSS serves /docs/category/{name}/feed.xml
+func (h *FeedHandler) CategoryRSS(w http.ResponseWriter, r *http.Request) {
+	h.serve(w, r, r.PathValue("name"), rssFormat)
+}
+
+func (h *FeedHandler) serve(w http.ResponseWriter, r *http.Request, category string, format feedFormat) {
+	docs, err := h.repo.List(r.Context())
+	if errors.Is(err, documents.ErrUnavailable) {
+		http.Error(w, "Documents service unavailable", http.StatusServiceUnavailable)
+		return
+	}
+	if err != nil {
+		http.Error(w, "Error loading documents", http.StatusInternalServerError)
+		return
+	}
+
+	if category != "" {
+		name, ok := findCategory(docs, category)
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		category = name
+	}
+
+	page := documents.ApplyQuery(docs, documents.Query{
+		Category: category,
+		Sort:     documents.SortUpdated,
+		PerPage:  feedSize,
+	})
+
+	// Every spelling of a category shares the canonical path, its ETag and
+	// its cache entry
+	path := feedPath(category, format)
+	etag := documentsETag(path, page.Documents)
+	lastModified := newestUpdate(page.Documents)
+
+	w.Header().Set("ETag", etag)
+	if !lastModified.IsZero() {
+		w.Header().Set("Last-Modified", lastModified.Format(http.TimeFormat))
+	}
+	w.Header().Set("Cache-Control", "public, max-age=300")
+
+	if notModified(r, etag, lastModified) {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	body, err := h.body(path, category, format, etag, page.Documents, lastModified)
+	if err != nil {
+		slog.Error("Failed to build feed", "path", path, "err", err)
+		http.Error(w, "Error building feed", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", format.contentType)
+	w.Write(body)
+}
+
+// body returns the feed document at path, reusing the last one built while
+// its documents are unchanged so articles are not re-rendered per request
+func (h *FeedHandler) body(path, category string, format feedFormat, etag string, docs []documents.Document, updated time.Time) ([]byte, error) {
+	h.mu.Lock()
+	cached, ok := h.cache[path]
+	h.mu.Unlock()
+	if ok && cached.etag == etag {
+		return cached.body, nil
+	}
+
+	f := feed.Feed{
+		Title:       siteTitle + " Docs",
+		Description: "Latest articles from the " + siteTitle + " documentation",
+		Link:        h.siteURL + "/docs/",
+		Self:        h.siteURL + path,
+		Updated:     updated,
+	}
+	if category != "" {
+		f.Title += ": " + category
+		f.Description = "Latest " + category + " articles from the " + siteTitle + " documentation"
+		f.Link = h.siteURL + docsIndexURL(documents.Query{Category: category})
+	}
+
+	for _, doc := range docs {
+		f.Items = append(f.Items, h.item(doc))
+	}
+
+	body, err := format.write(f)
+	if err != nil {
+		return nil, err
+	}
+
+	h.mu.Lock()
+	if _, ok := h.cache[path]; !ok && len(h.cache) >= maxCachedFeeds {
+		for stale := range h.cache {
+			delete(h.cache, stale)
+			break
+		}
+	}
+	h.cache[path] = cachedFeed{etag: etag, body: body}
+	h.mu.Unlock()
+
+	return body, nil
+}
+
+func (h *FeedHandler) item(doc documents.Document) feed.Item {
+	item := feed.Item{
+		Title:      doc.Title,
+		Link:       h.siteURL + "/docs/" + url.PathEscape(doc.ID),
+		Summary:    doc.Description,
+		Categories: append([]string{doc.Category}, doc.Tags...),
+		Updated:    parseUpdatedAt(doc.UpdatedAt),
+	}
+
+	if strings.HasPrefix(doc.CoverImage, "/") {
+		item.Image = h.siteURL + doc.CoverImage
+	} else {
+		item.Image = doc.CoverImage
+	}
+
+	content, err := base64.StdEncoding.DecodeString(doc.ContentB64)
+	if err == nil {
+		if rendered, err := h.markdown.Render(content); err == nil {
+			item.ContentHTML = string(rendered.HTML)
+		}
+	}
+	if item.ContentHTML == "" {
+		item.ContentHTML = "<p>" + html.EscapeString(doc.Description) + "</p>"
+	}
+
+	return item
+}
+
+func findCategory(docs []documents.Document, name string) (string, bool) {
+	for _, category := range documents.Categories(docs) {
+		if strings.EqualFold(category, name) {
+			return category, true
+		}
+	}
+	return "", false
+}
+
+// parseUpdatedAt reads the YYYY-MM-DD dates of documents
+func parseUpdatedAt(value string) time.Time {
+	t, _ := time.Parse("2006-01-02", value)
+	return t
+}
+
+func newestUpdate(docs []documents.Document) time.Time {
+	var newest time.Time
+	for _, doc := range docs {
+		if t := parseUpdatedAt(doc.UpdatedAt); t.After(newest) {
+			newest = t
+		}
+	}
+	return newest
+}
+
+// documentsETag identifies a response, such as a feed or a sitemap, by its
+// path and the exact documents in it
+func documentsETag(path string, docs []documents.Document) string {
+	h := sha256.New()
+	h.Write([]byte(path))
+	for _, doc := range docs {
+		for _, field := range []string{doc.ID, doc.UpdatedAt, doc.Title, doc.Description, doc.Category, strings.Join(doc.Tags, ","), doc.CoverImage, doc.ContentB64} {
+			h.Write([]byte{0})
+			h.Write([]byte(field))
+		}
+	}
+	return `"` + hex.EncodeToString(h.Sum(nil))[:32] + `"`
+}
+
+// notModified evaluates If-None-Match, or If-Modified-Since when there is no
+// If-None-Match, as RFC 9110 asks
+func notModified(r *http.Request, etag string, lastModified time.Time) bool {
+	if match := r.Header.Get("If-None-Match"); match != "" {
+		for _, candidate := range strings.Split(match, ",") {
+			candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+			if candidate == etag || candidate == "*" {
+				return true
+			}
+		}
+		return false
+	}
+
+	if since, err := http.ParseTime(r.Header.Get("If-Modified-Since")); err == nil && !lastModified.IsZero() {
+		return !lastModified.After(since)
+	}
+	return false
+}
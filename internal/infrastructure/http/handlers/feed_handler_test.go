@@ -0,0 +1,142 @@
+package handlers
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"markitos-it-app-website/internal/domain/documents"
+	"markitos-it-app-website/internal/infrastructure/markdown"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// stubRepository answers every call with docs, or with err when set
+type stubRepository struct {
+	docs []documents.Document
+	err  error
+}
+
+func (r stubRepository) List(context.Context) ([]documents.Document, error) {
+	return r.docs, r.err
+}
+
+func (r stubRepository) ListSummaries(_ context.Context, filter documents.Query) ([]documents.Document, error) {
+	if r.err != nil {
+		return nil, r.err
+	}
+	return documents.ApplyQuery(r.docs, documents.Query{Category: filter.Category, Tag: filter.Tag, PerPage: documents.MaxPerPage}).Documents, nil
+}
+
+func (r stubRepository) Get(_ context.Context, id string) (*documents.Document, error) {
+	if r.err != nil {
+		return nil, r.err
+	}
+	for _, doc := range r.docs {
+		if doc.ID == id {
+			return &doc, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: %s", documents.ErrNotFound, id)
+}
+
+func testDocuments() []documents.Document {
+	content := base64.StdEncoding.EncodeToString([]byte("## Setup\n\nInstall the tool.\n"))
+	return []documents.Document{
+		{ID: "grpc", Title: "gRPC services", Category: "Backend", Tags: []string{"go"}, UpdatedAt: "2025-02-01", ContentB64: content},
+		{ID: "k8s", Title: "Kubernetes basics", Category: "Cloud & DevOps", Tags: []string{"kubernetes"}, UpdatedAt: "2025-03-01", ContentB64: content},
+	}
+}
+
+func serveFeed(h *FeedHandler, path string) *httptest.ResponseRecorder {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/docs/feed.xml", h.RSS)
+	mux.HandleFunc("/docs/atom.xml", h.Atom)
+	mux.HandleFunc("/docs/feed.json", h.JSON)
+	mux.HandleFunc("/docs/category/{name}/feed.xml", h.CategoryRSS)
+
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+	return rec
+}
+
+func TestFeedHandlerCacheKeys(t *testing.T) {
+	h := NewFeedHandler(stubRepository{docs: testDocuments()}, markdown.NewRenderer(markdown.DefaultOptions()), "https://example.com")
+
+	var etag string
+	for _, path := range []string{
+		"/docs/category/Cloud%20&%20DevOps/feed.xml",
+		"/docs/category/cloud%20&%20devops/feed.xml",
+		"/docs/category/CLOUD%20%26%20DEVOPS/feed.xml",
+	} {
+		rec := serveFeed(h, path)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("GET %s = %d, want 200", path, rec.Code)
+		}
+		if etag == "" {
+			etag = rec.Header().Get("ETag")
+		} else if got := rec.Header().Get("ETag"); got != etag {
+			t.Errorf("GET %s ETag = %s, want %s like the other spellings", path, got, etag)
+		}
+		if self := "https://example.com/docs/category/Cloud%20&amp;%20DevOps/feed.xml"; !strings.Contains(rec.Body.String(), self) {
+			t.Errorf("GET %s does not link to itself as %s", path, self)
+		}
+	}
+
+	for _, path := range []string{"/docs/category/Frontend/feed.xml", "/docs/category/nope/feed.xml"} {
+		if rec := serveFeed(h, path); rec.Code != http.StatusNotFound {
+			t.Errorf("GET %s = %d, want 404", path, rec.Code)
+		}
+	}
+
+	serveFeed(h, "/docs/feed.xml")
+	serveFeed(h, "/docs/atom.xml")
+	serveFeed(h, "/docs/feed.json")
+
+	want := []string{
+		"/docs/category/Cloud%20&%20DevOps/feed.xml",
+		"/docs/feed.xml",
+		"/docs/atom.xml",
+		"/docs/feed.json",
+	}
+	if len(h.cache) != len(want) {
+		t.Errorf("cache has %d entries, want %d", len(h.cache), len(want))
+	}
+	for _, key := range want {
+		if _, ok := h.cache[key]; !ok {
+			t.Errorf("cache has no entry for %s", key)
+		}
+	}
+}
+
+func TestFeedHandlerCacheBound(t *testing.T) {
+	var docs []documents.Document
+	for i := range maxCachedFeeds + 10 {
+		docs = append(docs, documents.Document{ID: fmt.Sprintf("doc-%d", i), Category: fmt.Sprintf("Category %d", i), UpdatedAt: "2025-01-01"})
+	}
+	h := NewFeedHandler(stubRepository{docs: docs}, markdown.NewRenderer(markdown.DefaultOptions()), "https://example.com")
+
+	for i := range docs {
+		if rec := serveFeed(h, feedPath(docs[i].Category, rssFormat)); rec.Code != http.StatusOK {
+			t.Fatalf("GET feed of %s = %d, want 200", docs[i].Category, rec.Code)
+		}
+	}
+	if len(h.cache) > maxCachedFeeds {
+		t.Errorf("cache grew to %d entries, want at most %d", len(h.cache), maxCachedFeeds)
+	}
+}
+
+func TestFeedHandlerNotModified(t *testing.T) {
+	h := NewFeedHandler(stubRepository{docs: testDocuments()}, markdown.NewRenderer(markdown.DefaultOptions()), "https://example.com")
+
+	etag := serveFeed(h, "/docs/feed.xml").Header().Get("ETag")
+	req := httptest.NewRequest(http.MethodGet, "/docs/feed.xml", nil)
+	req.Header.Set("If-None-Match", etag)
+	rec := httptest.NewRecorder()
+	h.RSS(rec, req)
+
+	if rec.Code != http.StatusNotModified {
+		t.Errorf("conditional GET = %d, want 304", rec.Code)
+	}
+}
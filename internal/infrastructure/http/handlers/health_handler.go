@@ -0,0 +1,164 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"sync/atomic"
+	"time"
+)
+
+const healthCheckTimeout = 2 * time.Second
+
+// Status of a health check, and of a probe as a whole
+const (
+	HealthOK           = "ok"
+	HealthDegraded     = "degraded"
+	HealthFailing      = "failing"
+	HealthShuttingDown = "shutting_down"
+)
+
+// HealthCheck reports the status of one dependency
+type HealthCheck struct {
+	Name string
+	// Check returns a short description of the dependency, and an error when it is unhealthy
+	Check func(ctx context.Context) (detail string, err error)
+	// Startup checks have to pass once before /startupz succeeds
+	Startup bool
+	// HasFallback marks dependencies whose failure only degrades the site,
+	// e.g. the documents service while the embedded docs are served instead
+	HasFallback bool
+}
+
+// HealthHandler serves the Kubernetes probes:
+//   - /livez fails only if the process cannot answer at all
+//   - /startupz passes once every startup check has passed
+//   - /readyz runs every check, and fails as soon as the server starts shutting
+//     down so the pod leaves the Service before connections are drained
+//
+// ?verbose adds the detail and error of each check to the JSON response.
+type HealthHandler struct {
+	checks []HealthCheck
+	// degradedReady keeps /readyz passing while dependencies with a fallback fail
+	degradedReady bool
+
+	started      atomic.Bool
+	shuttingDown atomic.Bool
+}
+
+func NewHealthHandler(degradedReady bool) *HealthHandler {
+	return &HealthHandler{degradedReady: degradedReady}
+}
+
+// AddCheck registers a check; call it before serving
+func (h *HealthHandler) AddCheck(check HealthCheck) {
+	h.checks = append(h.checks, check)
+}
+
+// ShuttingDown makes /readyz fail from now on
+func (h *HealthHandler) ShuttingDown() {
+	h.shuttingDown.Store(true)
+}
+
+type healthResponse struct {
+	Status string                 `json:"status"`
+	Checks map[string]checkResult `json:"checks,omitempty"`
+}
+
+type checkResult struct {
+	Status   string `json:"status"`
+	Detail   string `json:"detail,omitempty"`
+	Error    string `json:"error,omitempty"`
+	Duration string `json:"duration,omitempty"`
+}
+
+// Live serves /livez
+func (h *HealthHandler) Live(w http.ResponseWriter, r *http.Request) {
+	writeHealth(w, r, http.StatusOK, healthResponse{Status: HealthOK})
+}
+
+// Startup serves /startupz
+func (h *HealthHandler) Startup(w http.ResponseWriter, r *http.Request) {
+	if h.started.Load() {
+		writeHealth(w, r, http.StatusOK, healthResponse{Status: HealthOK})
+		return
+	}
+
+	response := h.run(r, func(check HealthCheck) bool { return check.Startup })
+	if response.Status != HealthOK {
+		writeHealth(w, r, http.StatusServiceUnavailable, response)
+		return
+	}
+
+	h.started.Store(true)
+	writeHealth(w, r, http.StatusOK, response)
+}
+
+// Ready serves /readyz
+func (h *HealthHandler) Ready(w http.ResponseWriter, r *http.Request) {
+	if h.shuttingDown.Load() {
+		writeHealth(w, r, http.StatusServiceUnavailable, healthResponse{Status: HealthShuttingDown})
+		return
+	}
+
+	response := h.run(r, func(HealthCheck) bool { return true })
+	status := http.StatusOK
+	if response.Status == HealthFailing {
+		status = http.StatusServiceUnavailable
+	}
+	writeHealth(w, r, status, response)
+}
+
+func (h *HealthHandler) run(r *http.Request, include func(HealthCheck) bool) healthResponse {
+	response := healthResponse{Status: HealthOK, Checks: make(map[string]checkResult)}
+
+	for _, check := range h.checks {
+		if !include(check) {
+			continue
+		}
+
+		ctx, cancel := context.WithTimeout(r.Context(), healthCheckTimeout)
+		start := time.Now()
+		detail, err := check.Check(ctx)
+		cancel()
+
+		result := checkResult{Status: HealthOK, Detail: detail, Duration: time.Since(start).String()}
+		if err != nil {
+			result.Error = err.Error()
+			result.Status = HealthFailing
+			if check.HasFallback && h.degradedReady {
+				result.Status = HealthDegraded
+			}
+		}
+
+		switch {
+		case result.Status == HealthFailing:
+			response.Status = HealthFailing
+		case result.Status == HealthDegraded && response.Status == HealthOK:
+			response.Status = HealthDegraded
+		}
+		response.Checks[check.Name] = result
+	}
+
+	return response
+}
+
+func writeHealth(w http.ResponseWriter, r *http.Request, status int, response healthResponse) {
+	// ?verbose and ?verbose=true both ask for details
+	verbose := r.URL.Query().Has("verbose")
+	if value := r.URL.Query().Get("verbose"); value != "" {
+		verbose, _ = strconv.ParseBool(value)
+	}
+	if !verbose {
+		for name, result := range response.Checks {
+			response.Checks[name] = checkResult{Status: result.Status}
+		}
+	}
+
+	body, _ := json.Marshal(response)
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(status)
+	w.Write(append(body, '\n'))
+}
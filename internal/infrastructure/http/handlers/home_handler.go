@@ -8,10 +8,11 @@ import (
 )
 
 type HomeHandler struct {
-	tmpl *template.Template
+	tmpl    *template.Template
+	siteURL string
 }
 
-func NewHomeHandler() (*HomeHandler, error) {
+func NewHomeHandler(siteURL string) (*HomeHandler, error) {
 	tmpl, err := template.New("base.html").ParseFS(
 		templates.FS(),
 		"shared/base.html",
@@ -28,7 +29,7 @@ func NewHomeHandler() (*HomeHandler, error) {
 	if err != nil {
 		return nil, err
 	}
-	return &HomeHandler{tmpl: tmpl}, nil
+	return &HomeHandler{tmpl: tmpl, siteURL: siteURL}, nil
 }
 
 func (h *HomeHandler) Index(w http.ResponseWriter, r *http.Request) {
@@ -53,6 +54,7 @@ func (h *HomeHandler) Index(w http.ResponseWriter, r *http.Request) {
 	data := map[string]interface{}{
 		"PageClass":     "home-page",
 		"Title":         "Home",
+		"Meta":          websiteMeta(h.siteURL, "/", "MarkitosIT", "Guides and documentation about DevOps, cloud infrastructure and software architecture"),
 		"ActiveSection": "home",
 		"ResultsCount":  "2,450",
 		"Packages": []map[string]string{
@@ -0,0 +1,105 @@
+package handlers
+
+import (
+	"html/template"
+	"io"
+	"markitos-it-app-website/internal/domain/search"
+	"markitos-it-app-website/internal/templates"
+	"net/http"
+	"strings"
+)
+
+const maxSearchResults = 50
+
+type SearchHandler struct {
+	searcher *search.Searcher
+	tmpl     *template.Template
+	siteURL  string
+}
+
+func NewSearchHandler(searcher *search.Searcher, siteURL string) (*SearchHandler, error) {
+	tmpl, err := template.New("base.html").ParseFS(
+		templates.FS(),
+		"shared/base.html",
+		"shared/head.html",
+		"shared/navbar.html",
+		"shared/sidebar.html",
+		"shared/scripts.html",
+		"shared/styles.css",
+		"shared/common.js",
+		"docs/search/content.html",
+		"docs/search/styles.css",
+		"docs/search/script.js",
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &SearchHandler{searcher: searcher, tmpl: tmpl, siteURL: siteURL}, nil
+}
+
+func (h *SearchHandler) Search(w http.ResponseWriter, r *http.Request) {
+	query := strings.TrimSpace(r.URL.Query().Get("q"))
+
+	var results []search.Result
+	if query != "" {
+		var err error
+		results, err = h.searcher.Search(r.Context(), query, maxSearchResults)
+		if err != nil {
+			http.Error(w, "Error searching documents", http.StatusInternalServerError)
+			return
+		}
+	}
+
+	sharedCSS, _ := templates.FS().Open("shared/styles.css")
+	sharedCSSBytes, _ := io.ReadAll(sharedCSS)
+	sharedCSS.Close()
+
+	sharedJS, _ := templates.FS().Open("shared/common.js")
+	sharedJSBytes, _ := io.ReadAll(sharedJS)
+	sharedJS.Close()
+
+	pageCSS, _ := templates.FS().Open("docs/search/styles.css")
+	pageCSSBytes, _ := io.ReadAll(pageCSS)
+	pageCSS.Close()
+
+	pageJS, _ := templates.FS().Open("docs/search/script.js")
+	pageJSBytes, _ := io.ReadAll(pageJS)
+	pageJS.Close()
+
+	resultsInterface := make([]map[string]interface{}, len(results))
+	for i, result := range results {
+		resultsInterface[i] = map[string]interface{}{
+			"ID":          result.Document.ID,
+			"Title":       result.Document.Title,
+			"Description": result.Document.Description,
+			"Category":    result.Document.Category,
+			"UpdatedAt":   result.Document.UpdatedAt,
+			"Snippet":     result.Snippet,
+		}
+	}
+
+	title := "Search"
+	if query != "" {
+		title = "Search: " + query
+	}
+
+	meta := websiteMeta(h.siteURL, "/docs/search", title, "Full-text search across the MarkitosIT documentation")
+	meta.Robots = "noindex, follow"
+
+	data := map[string]interface{}{
+		"PageClass":     "docs-search-page",
+		"Title":         title,
+		"Meta":          meta,
+		"ActiveSection": "docs",
+		"Query":         query,
+		"Results":       resultsInterface,
+		"SharedStyles":  template.CSS(string(sharedCSSBytes)),
+		"SharedScript":  template.JS(string(sharedJSBytes)),
+		"PageStyles":    template.CSS(string(pageCSSBytes)),
+		"PageScript":    template.JS(string(pageJSBytes)),
+	}
+
+	if err := h.tmpl.ExecuteTemplate(w, "base.html", data); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,104 @@
+package handlers
+
+import (
+	"markitos-it-app-website/internal/domain/documents"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// PageMeta is the metadata shared/head.html renders for search engines and
+// link previews: canonical URL, description, OpenGraph, Twitter card and
+// optional JSON-LD
+type PageMeta struct {
+	Title       string
+	Description string
+	Canonical   string
+	// Type is the og:type, "website" or "article"
+	Type  string
+	Image string
+	// Robots is the robots meta tag, empty to let crawlers index the page
+	Robots string
+
+	// Article fields, only set on documents
+	Section  string
+	Tags     []string
+	Modified string
+
+	// JSONLD is marshalled into a <script type="application/ld+json">
+	JSONLD any
+}
+
+// websiteMeta describes a plain page of the site
+func websiteMeta(siteURL, path, title, description string) PageMeta {
+	return PageMeta{
+		Title:       title,
+		Description: description,
+		Canonical:   strings.TrimRight(siteURL, "/") + path,
+		Type:        "website",
+	}
+}
+
+type techArticle struct {
+	Context          string         `json:"@context"`
+	Type             string         `json:"@type"`
+	Headline         string         `json:"headline"`
+	Description      string         `json:"description,omitempty"`
+	URL              string         `json:"url"`
+	MainEntityOfPage string         `json:"mainEntityOfPage"`
+	Image            string         `json:"image,omitempty"`
+	DatePublished    string         `json:"datePublished,omitempty"`
+	DateModified     string         `json:"dateModified,omitempty"`
+	ArticleSection   string         `json:"articleSection,omitempty"`
+	Keywords         string         `json:"keywords,omitempty"`
+	WordCount        int            `json:"wordCount,omitempty"`
+	TimeRequired     string         `json:"timeRequired,omitempty"`
+	Publisher        schemaOrgThing `json:"publisher"`
+}
+
+type schemaOrgThing struct {
+	Type string `json:"@type"`
+	Name string `json:"name"`
+}
+
+// documentMeta describes a document as an article, with TechArticle JSON-LD
+func documentMeta(siteURL string, doc *documents.Document) PageMeta {
+	siteURL = strings.TrimRight(siteURL, "/")
+	canonical := siteURL + "/docs/" + url.PathEscape(doc.ID)
+
+	image := doc.CoverImage
+	if strings.HasPrefix(image, "/") {
+		image = siteURL + image
+	}
+
+	article := techArticle{
+		Context:          "https://schema.org",
+		Type:             "TechArticle",
+		Headline:         doc.Title,
+		Description:      doc.Description,
+		URL:              canonical,
+		MainEntityOfPage: canonical,
+		Image:            image,
+		DatePublished:    doc.UpdatedAt,
+		DateModified:     doc.UpdatedAt,
+		ArticleSection:   doc.Category,
+		Keywords:         strings.Join(doc.Tags, ", "),
+		WordCount:        doc.Stats.Words,
+		Publisher:        schemaOrgThing{Type: "Organization", Name: siteTitle},
+	}
+	if doc.Stats.ReadingMinutes > 0 {
+		article.TimeRequired = "PT" + strconv.Itoa(doc.Stats.ReadingMinutes) + "M"
+	}
+
+	return PageMeta{
+		Title:       doc.Title,
+		Description: doc.Description,
+		Canonical:   canonical,
+		Type:        "article",
+		Image:       image,
+		Section:     doc.Category,
+		Tags:        doc.Tags,
+		Modified:    doc.UpdatedAt,
+		JSONLD:      article,
+	}
+}
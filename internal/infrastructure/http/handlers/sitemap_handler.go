@@ -0,0 +1,161 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"markitos-it-app-website/internal/domain/documents"
+	"markitos-it-app-website/internal/infrastructure/sitemap"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// SitemapHandler serves sitemap.xml and robots.txt
+type SitemapHandler struct {
+	repo    documents.Repository
+	siteURL string
+	robots  sitemap.RobotsOptions
+}
+
+// NewSitemapHandler creates a sitemap handler; siteURL prefixes every URL
+func NewSitemapHandler(repo documents.Repository, siteURL string, robots sitemap.RobotsOptions) *SitemapHandler {
+	return &SitemapHandler{
+		repo:    repo,
+		siteURL: strings.TrimRight(siteURL, "/"),
+		robots:  robots,
+	}
+}
+
+// Sitemap serves /sitemap.xml: the sitemap itself, or a sitemap index
+// pointing at /sitemap/{n}.xml when there are more than sitemap.MaxURLs URLs
+func (h *SitemapHandler) Sitemap(w http.ResponseWriter, r *http.Request) {
+	docs, ok := h.documents(w, r)
+	if !ok {
+		return
+	}
+
+	urls := h.urls(docs)
+	if len(urls) <= sitemap.MaxURLs {
+		h.write(w, r, docs, func() ([]byte, error) { return sitemap.Write(urls) })
+		return
+	}
+
+	var parts []sitemap.URL
+	for start := 0; start < len(urls); start += sitemap.MaxURLs {
+		part := urls[start:min(start+sitemap.MaxURLs, len(urls))]
+		parts = append(parts, sitemap.URL{
+			Loc:     fmt.Sprintf("%s/sitemap/%d.xml", h.siteURL, len(parts)+1),
+			LastMod: newestLastMod(part),
+		})
+	}
+	h.write(w, r, docs, func() ([]byte, error) { return sitemap.WriteIndex(parts) })
+}
+
+// SitemapPage serves /sitemap/{page}, the pages of a sitemap index
+func (h *SitemapHandler) SitemapPage(w http.ResponseWriter, r *http.Request) {
+	page, err := strconv.Atoi(strings.TrimSuffix(r.PathValue("page"), ".xml"))
+	if err != nil || page < 1 {
+		http.NotFound(w, r)
+		return
+	}
+
+	docs, ok := h.documents(w, r)
+	if !ok {
+		return
+	}
+
+	urls := h.urls(docs)
+	start := (page - 1) * sitemap.MaxURLs
+	if len(urls) <= sitemap.MaxURLs || start >= len(urls) {
+		http.NotFound(w, r)
+		return
+	}
+
+	part := urls[start:min(start+sitemap.MaxURLs, len(urls))]
+	h.write(w, r, docs, func() ([]byte, error) { return sitemap.Write(part) })
+}
+
+// Robots serves /robots.txt
+func (h *SitemapHandler) Robots(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	w.Header().Set("Cache-Control", "public, max-age=3600")
+	w.Write(sitemap.WriteRobots(h.robots, h.siteURL+"/sitemap.xml"))
+}
+
+func (h *SitemapHandler) documents(w http.ResponseWriter, r *http.Request) ([]documents.Document, bool) {
+	docs, err := h.repo.ListSummaries(r.Context(), documents.Query{})
+	if errors.Is(err, documents.ErrUnavailable) {
+		http.Error(w, "Documents service unavailable", http.StatusServiceUnavailable)
+		return nil, false
+	}
+	if err != nil {
+		http.Error(w, "Error loading documents", http.StatusInternalServerError)
+		return nil, false
+	}
+	return docs, true
+}
+
+// urls lists the home page, the docs index, every category and every document
+func (h *SitemapHandler) urls(docs []documents.Document) []sitemap.URL {
+	urls := []sitemap.URL{
+		{Loc: h.siteURL + "/"},
+		{Loc: h.siteURL + "/docs/", LastMod: newestUpdate(docs)},
+	}
+
+	for _, category := range documents.Categories(docs) {
+		page := documents.ApplyQuery(docs, documents.Query{Category: category, PerPage: documents.MaxPerPage})
+		urls = append(urls, sitemap.URL{
+			Loc:     h.siteURL + docsIndexURL(documents.Query{Category: category}),
+			LastMod: newestUpdate(page.Documents),
+		})
+	}
+
+	for _, doc := range docs {
+		urls = append(urls, sitemap.URL{
+			Loc:     h.siteURL + "/docs/" + url.PathEscape(doc.ID),
+			LastMod: parseUpdatedAt(doc.UpdatedAt),
+		})
+	}
+
+	return urls
+}
+
+// write answers conditional requests from the documents' ETag before building the body
+func (h *SitemapHandler) write(w http.ResponseWriter, r *http.Request, docs []documents.Document, build func() ([]byte, error)) {
+	etag := documentsETag(r.URL.Path, docs)
+	lastModified := newestUpdate(docs)
+
+	w.Header().Set("ETag", etag)
+	if !lastModified.IsZero() {
+		w.Header().Set("Last-Modified", lastModified.Format(http.TimeFormat))
+	}
+	w.Header().Set("Cache-Control", "public, max-age=3600")
+
+	if notModified(r, etag, lastModified) {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	body, err := build()
+	if err != nil {
+		slog.Error("Failed to build sitemap", "path", r.URL.Path, "err", err)
+		http.Error(w, "Error building sitemap", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/xml; charset=utf-8")
+	w.Write(body)
+}
+
+func newestLastMod(urls []sitemap.URL) time.Time {
+	var newest time.Time
+	for _, u := range urls {
+		if u.LastMod.After(newest) {
+			newest = u.LastMod
+		}
+	}
+	return newest
+}
@@ -0,0 +1,57 @@
+package handlers
+
+import (
+	"markitos-it-app-website/internal/domain/search"
+	"markitos-it-app-website/internal/infrastructure/markdown"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// TestPageTemplates parses every page template set from templates.FS() and
+// renders each page once, so a broken template fails here instead of at startup
+func TestPageTemplates(t *testing.T) {
+	repo := stubRepository{docs: testDocuments()}
+
+	docs, err := NewDocsHandler(repo, markdown.NewRenderer(markdown.DefaultOptions()), "https://example.com")
+	if err != nil {
+		t.Fatalf("NewDocsHandler error = %v", err)
+	}
+	searchHandler, err := NewSearchHandler(search.NewSearcher(repo), "https://example.com")
+	if err != nil {
+		t.Fatalf("NewSearchHandler error = %v", err)
+	}
+	home, err := NewHomeHandler("https://example.com")
+	if err != nil {
+		t.Fatalf("NewHomeHandler error = %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		handler http.HandlerFunc
+		path    string
+		want    string
+	}{
+		{name: "home", handler: home.Index, path: "/", want: "</html>"},
+		{name: "docs index", handler: docs.Index, path: "/docs/", want: "Kubernetes basics"},
+		{name: "docs index filtered", handler: docs.Index, path: "/docs/?category=backend&tag=go&q=grpc&sort=title", want: "gRPC services"},
+		{name: "docs view", handler: docs.View, path: "/docs/k8s", want: "Install the tool."},
+		{name: "search", handler: searchHandler.Search, path: "/docs/search?q=install", want: "Kubernetes basics"},
+		{name: "empty search", handler: searchHandler.Search, path: "/docs/search", want: "</html>"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			tt.handler(rec, httptest.NewRequest(http.MethodGet, tt.path, nil))
+
+			if rec.Code != http.StatusOK {
+				t.Fatalf("GET %s = %d: %s", tt.path, rec.Code, rec.Body.String())
+			}
+			if body := rec.Body.String(); !strings.Contains(body, tt.want) {
+				t.Errorf("GET %s does not contain %q", tt.path, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,204 @@
+package markdown
+
+import (
+	"fmt"
+	"html"
+	"regexp"
+	"strings"
+
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/parser"
+	"github.com/yuin/goldmark/renderer"
+	"github.com/yuin/goldmark/text"
+	"github.com/yuin/goldmark/util"
+)
+
+// KindAdmonition is the node kind of an Admonition
+var KindAdmonition = ast.NewNodeKind("Admonition")
+
+// Admonition is a callout box such as a note or a warning. It is written
+// either as a GitHub alert:
+//
+//	> [!WARNING]
+//	> Back up the volume first.
+//
+// or as a container, optionally with a title:
+//
+//	:::tip Faster builds
+//	Enable the layer cache.
+//	:::
+//
+// Containers nest when the outer one uses a longer fence (::::).
+type Admonition struct {
+	ast.BaseBlock
+	AdmonitionKind string
+	Title          string
+
+	fence int
+}
+
+// Kind implements ast.Node
+func (n *Admonition) Kind() ast.NodeKind {
+	return KindAdmonition
+}
+
+// Dump implements ast.Node
+func (n *Admonition) Dump(source []byte, level int) {
+	ast.DumpHelper(n, source, level, map[string]string{
+		"AdmonitionKind": n.AdmonitionKind,
+		"Title":          n.Title,
+	}, nil)
+}
+
+// admonitionTitles are the supported kinds, the same five GitHub alerts have
+var admonitionTitles = map[string]string{
+	"note":      "Note",
+	"tip":       "Tip",
+	"important": "Important",
+	"warning":   "Warning",
+	"caution":   "Caution",
+}
+
+// admonitionAliases are extra container names people bring from other tools
+var admonitionAliases = map[string]string{
+	"info":      "note",
+	"hint":      "tip",
+	"danger":    "caution",
+	"attention": "warning",
+}
+
+func admonitionKind(name string) (string, bool) {
+	name = strings.ToLower(name)
+	if alias, ok := admonitionAliases[name]; ok {
+		name = alias
+	}
+	_, ok := admonitionTitles[name]
+	return name, ok
+}
+
+var (
+	alertMarker     = regexp.MustCompile(`^ {0,3}>[ \t]?\[!([A-Za-z]+)\][ \t]*$`)
+	containerOpen   = regexp.MustCompile(`^ {0,3}(:{3,})[ \t]*([A-Za-z]+)(?:[ \t]+(.*?))?[ \t]*$`)
+	containerClose  = regexp.MustCompile(`^ {0,3}(:{3,})[ \t]*$`)
+	blockquoteLines = parser.NewBlockquoteParser()
+)
+
+// alertParser takes over blockquotes whose first line is a [!KIND] marker
+type alertParser struct{}
+
+func (p *alertParser) Trigger() []byte {
+	return []byte{'>'}
+}
+
+func (p *alertParser) Open(parent ast.Node, reader text.Reader, pc parser.Context) (ast.Node, parser.State) {
+	line, _ := reader.PeekLine()
+	m := alertMarker.FindSubmatch(util.TrimRightSpace(line))
+	if m == nil {
+		return nil, parser.NoChildren
+	}
+	kind, ok := admonitionKind(string(m[1]))
+	if !ok {
+		return nil, parser.NoChildren
+	}
+
+	reader.AdvanceToEOL()
+	return &Admonition{AdmonitionKind: kind}, parser.HasChildren
+}
+
+// Continue consumes the "> " prefix of the following lines exactly like a blockquote
+func (p *alertParser) Continue(node ast.Node, reader text.Reader, pc parser.Context) parser.State {
+	return blockquoteLines.Continue(node, reader, pc)
+}
+
+func (p *alertParser) Close(node ast.Node, reader text.Reader, pc parser.Context) {}
+
+func (p *alertParser) CanInterruptParagraph() bool {
+	return true
+}
+
+func (p *alertParser) CanAcceptIndentedLine() bool {
+	return false
+}
+
+// containerParser parses :::kind ... ::: blocks
+type containerParser struct{}
+
+func (p *containerParser) Trigger() []byte {
+	return []byte{':'}
+}
+
+func (p *containerParser) Open(parent ast.Node, reader text.Reader, pc parser.Context) (ast.Node, parser.State) {
+	line, _ := reader.PeekLine()
+	m := containerOpen.FindSubmatch(util.TrimRightSpace(line))
+	if m == nil {
+		return nil, parser.NoChildren
+	}
+	kind, ok := admonitionKind(string(m[2]))
+	if !ok {
+		return nil, parser.NoChildren
+	}
+
+	reader.AdvanceToEOL()
+	return &Admonition{AdmonitionKind: kind, Title: string(m[3]), fence: len(m[1])}, parser.HasChildren
+}
+
+func (p *containerParser) Continue(node ast.Node, reader text.Reader, pc parser.Context) parser.State {
+	line, _ := reader.PeekLine()
+	m := containerClose.FindSubmatch(util.TrimRightSpace(line))
+	if m != nil && len(m[1]) >= node.(*Admonition).fence {
+		reader.AdvanceToEOL()
+		return parser.Close
+	}
+	return parser.Continue | parser.HasChildren
+}
+
+func (p *containerParser) Close(node ast.Node, reader text.Reader, pc parser.Context) {}
+
+func (p *containerParser) CanInterruptParagraph() bool {
+	return true
+}
+
+func (p *containerParser) CanAcceptIndentedLine() bool {
+	return false
+}
+
+type admonitionRenderer struct{}
+
+func (r *admonitionRenderer) RegisterFuncs(reg renderer.NodeRendererFuncRegisterer) {
+	reg.Register(KindAdmonition, r.render)
+}
+
+func (r *admonitionRenderer) render(w util.BufWriter, source []byte, node ast.Node, entering bool) (ast.WalkStatus, error) {
+	n := node.(*Admonition)
+	if !entering {
+		_, _ = w.WriteString("</aside>\n")
+		return ast.WalkContinue, nil
+	}
+
+	title := n.Title
+	if title == "" {
+		title = admonitionTitles[n.AdmonitionKind]
+	}
+
+	fmt.Fprintf(w, "<aside class=\"admonition admonition-%s\" role=\"note\">\n", n.AdmonitionKind)
+	fmt.Fprintf(w, "<p class=\"admonition-title\">%s</p>\n", html.EscapeString(title))
+	return ast.WalkContinue, nil
+}
+
+type admonitions struct{}
+
+// Admonitions is a goldmark extension for note, tip, important, warning and
+// caution callouts written as GitHub alerts or ::: containers
+var Admonitions goldmark.Extender = &admonitions{}
+
+func (e *admonitions) Extend(m goldmark.Markdown) {
+	m.Parser().AddOptions(parser.WithBlockParsers(
+		// Ahead of the blockquote parser (800) so alerts are not plain quotes
+		util.Prioritized(&alertParser{}, 790),
+		util.Prioritized(&containerParser{}, 160),
+	))
+	m.Renderer().AddOptions(renderer.WithNodeRenderers(
+		util.Prioritized(&admonitionRenderer{}, 500),
+	))
+}
@@ -0,0 +1,275 @@
+package markdown
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"html"
+	"log/slog"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/parser"
+	"github.com/yuin/goldmark/renderer"
+	"github.com/yuin/goldmark/text"
+	"github.com/yuin/goldmark/util"
+	"golang.org/x/sync/singleflight"
+)
+
+const (
+	diagramTimeout = 10 * time.Second
+	// diagramRetryAfter keeps a broken diagram from re-running the renderer
+	// (and waiting for its timeout) on every page view
+	diagramRetryAfter = time.Minute
+	// maxCachedDiagrams bounds the SVG cache; diagrams only change when a
+	// document is edited, so this comfortably holds every diagram on the site
+	maxCachedDiagrams = 512
+)
+
+// DiagramCommands maps a fence language to the command that renders it.
+// The command reads the diagram source on stdin and writes SVG to stdout.
+type DiagramCommands map[string][]string
+
+// DefaultDiagramCommands uses the mermaid CLI and PlantUML
+func DefaultDiagramCommands() DiagramCommands {
+	return DiagramCommands{
+		"mermaid":  {"mmdc", "--input", "-", "--output", "-", "--outputFormat", "svg", "--quiet"},
+		"plantuml": {"plantuml", "-tsvg", "-pipe"},
+	}
+}
+
+// DiagramRenderer turns diagram sources into SVG with local binaries,
+// caching the results by content hash
+type DiagramRenderer struct {
+	commands DiagramCommands
+
+	mu       sync.Mutex
+	cache    map[string][]byte
+	failures map[string]failedDiagram
+	group    singleflight.Group
+}
+
+type failedDiagram struct {
+	err error
+	at  time.Time
+}
+
+// NewDiagramRenderer creates a renderer for the given commands. Languages
+// whose binary is not installed are dropped, so their fences are shown as code.
+func NewDiagramRenderer(commands DiagramCommands) *DiagramRenderer {
+	available := make(DiagramCommands, len(commands))
+	for lang, cmd := range commands {
+		if len(cmd) == 0 {
+			continue
+		}
+		if _, err := exec.LookPath(cmd[0]); err != nil {
+			slog.Warn("Diagrams disabled", "lang", lang, "err", err)
+			continue
+		}
+		available[lang] = cmd
+	}
+
+	return &DiagramRenderer{
+		commands: available,
+		cache:    make(map[string][]byte),
+		failures: make(map[string]failedDiagram),
+	}
+}
+
+// Supports reports whether lang fences are rendered as diagrams
+func (d *DiagramRenderer) Supports(lang string) bool {
+	_, ok := d.commands[lang]
+	return ok
+}
+
+// Render returns the SVG for source, running the renderer at most once per
+// distinct diagram
+func (d *DiagramRenderer) Render(ctx context.Context, lang string, source []byte) ([]byte, error) {
+	cmd, ok := d.commands[lang]
+	if !ok {
+		return nil, fmt.Errorf("no renderer for %s diagrams", lang)
+	}
+
+	sum := sha256.Sum256(append([]byte(lang+"\x00"), source...))
+	key := hex.EncodeToString(sum[:])
+
+	d.mu.Lock()
+	svg, ok := d.cache[key]
+	failed, hasFailed := d.failures[key]
+	d.mu.Unlock()
+	if ok {
+		return svg, nil
+	}
+	if hasFailed && time.Since(failed.at) < diagramRetryAfter {
+		return nil, failed.err
+	}
+
+	v, err, _ := d.group.Do(key, func() (interface{}, error) {
+		svg, err := run(ctx, cmd, diagramInput(lang, source))
+
+		d.mu.Lock()
+		defer d.mu.Unlock()
+
+		if err != nil {
+			slog.Warn("Failed to render diagram", "lang", lang, "err", err)
+			d.failures[key] = failedDiagram{err: err, at: time.Now()}
+			return nil, err
+		}
+		delete(d.failures, key)
+
+		if len(d.cache) >= maxCachedDiagrams {
+			for k := range d.cache {
+				delete(d.cache, k)
+				break
+			}
+		}
+		d.cache[key] = svg
+
+		return svg, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return v.([]byte), nil
+}
+
+func run(ctx context.Context, command []string, input []byte) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(ctx, diagramTimeout)
+	defer cancel()
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, command[0], command[1:]...)
+	cmd.Stdin = bytes.NewReader(input)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("%s: %w: %s", command[0], err, strings.TrimSpace(stderr.String()))
+	}
+
+	svg := stdout.Bytes()
+	if !bytes.Contains(svg, []byte("<svg")) {
+		return nil, fmt.Errorf("%s: output is not SVG", command[0])
+	}
+	return svg, nil
+}
+
+// diagramInput adds the @startuml/@enduml wrapper PlantUML needs when the
+// fence leaves it out
+func diagramInput(lang string, source []byte) []byte {
+	if lang != "plantuml" || bytes.Contains(source, []byte("@start")) {
+		return source
+	}
+	return []byte("@startuml\n" + string(source) + "@enduml\n")
+}
+
+// KindDiagram is the node kind of a Diagram
+var KindDiagram = ast.NewNodeKind("Diagram")
+
+// Diagram replaces a ```mermaid or ```plantuml fenced code block
+type Diagram struct {
+	ast.BaseBlock
+	Language string
+	Source   []byte
+}
+
+// Kind implements ast.Node
+func (n *Diagram) Kind() ast.NodeKind {
+	return KindDiagram
+}
+
+// Dump implements ast.Node
+func (n *Diagram) Dump(source []byte, level int) {
+	ast.DumpHelper(n, source, level, map[string]string{"Language": n.Language}, nil)
+}
+
+type diagramTransformer struct {
+	diagrams *DiagramRenderer
+}
+
+// Transform swaps diagram fences for Diagram nodes before the highlighter sees them
+func (t *diagramTransformer) Transform(doc *ast.Document, reader text.Reader, pc parser.Context) {
+	source := reader.Source()
+
+	var fences []*ast.FencedCodeBlock
+	_ = ast.Walk(doc, func(n ast.Node, entering bool) (ast.WalkStatus, error) {
+		if fence, ok := n.(*ast.FencedCodeBlock); ok && entering {
+			if t.diagrams.Supports(string(fence.Language(source))) {
+				fences = append(fences, fence)
+			}
+		}
+		return ast.WalkContinue, nil
+	})
+
+	for _, fence := range fences {
+		var body bytes.Buffer
+		lines := fence.Lines()
+		for i := 0; i < lines.Len(); i++ {
+			segment := lines.At(i)
+			body.Write(segment.Value(source))
+		}
+
+		diagram := &Diagram{Language: string(fence.Language(source)), Source: body.Bytes()}
+		fence.Parent().ReplaceChild(fence.Parent(), fence, diagram)
+	}
+}
+
+type diagramRenderer struct {
+	diagrams *DiagramRenderer
+}
+
+func (r *diagramRenderer) RegisterFuncs(reg renderer.NodeRendererFuncRegisterer) {
+	reg.Register(KindDiagram, r.render)
+}
+
+// render inlines the SVG so its text can be selected and styles.css can theme
+// it; the sanitizer keeps only drawing elements and presentation attributes.
+// If rendering fails the reader still gets the diagram source.
+func (r *diagramRenderer) render(w util.BufWriter, source []byte, node ast.Node, entering bool) (ast.WalkStatus, error) {
+	if !entering {
+		return ast.WalkContinue, nil
+	}
+
+	n := node.(*Diagram)
+	svg, err := r.diagrams.Render(context.Background(), n.Language, n.Source)
+	if err != nil {
+		fmt.Fprintf(w, "<pre class=\"diagram-source\"><code class=\"language-%s\">%s</code></pre>\n", n.Language, html.EscapeString(string(n.Source)))
+		return ast.WalkSkipChildren, nil
+	}
+
+	fmt.Fprintf(w, "<figure class=\"diagram diagram-%s\">%s</figure>\n", n.Language, svgElement(svg))
+	return ast.WalkSkipChildren, nil
+}
+
+// svgElement drops the XML declaration, doctype and comments renderers put
+// before the <svg> element, which have no place inside an HTML document
+func svgElement(svg []byte) []byte {
+	if i := bytes.Index(svg, []byte("<svg")); i >= 0 {
+		svg = svg[i:]
+	}
+	return bytes.TrimSpace(svg)
+}
+
+type diagramExtension struct {
+	diagrams *DiagramRenderer
+}
+
+// Diagrams is a goldmark extension that renders diagram fences with d
+func Diagrams(d *DiagramRenderer) goldmark.Extender {
+	return &diagramExtension{diagrams: d}
+}
+
+func (e *diagramExtension) Extend(m goldmark.Markdown) {
+	m.Parser().AddOptions(parser.WithASTTransformers(
+		util.Prioritized(&diagramTransformer{diagrams: e.diagrams}, 100),
+	))
+	m.Renderer().AddOptions(renderer.WithNodeRenderers(
+		util.Prioritized(&diagramRenderer{diagrams: e.diagrams}, 500),
+	))
+}
@@ -0,0 +1,63 @@
+package markdown
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/alecthomas/chroma/v2"
+	chromahtml "github.com/alecthomas/chroma/v2/formatters/html"
+	"github.com/alecthomas/chroma/v2/styles"
+)
+
+const (
+	// highlightClassPrefix namespaces chroma's short token classes (.k, .s...)
+	// so they cannot clash with the site's own CSS
+	highlightClassPrefix = "hl-"
+
+	lightHighlightStyle = "github"
+	darkHighlightStyle  = "github-dark"
+)
+
+var (
+	highlightCSSOnce sync.Once
+	highlightCSS     string
+)
+
+// HighlightCSS returns the stylesheet for highlighted code blocks: a light
+// theme by default and a dark theme when the reader prefers dark mode.
+// Rules are scoped to .doc-content so they win over the generic pre/code
+// styles of the docs view.
+func HighlightCSS() string {
+	highlightCSSOnce.Do(func() {
+		var b strings.Builder
+		writeHighlightTheme(&b, lightHighlightStyle)
+
+		b.WriteString("\n@media (prefers-color-scheme: dark) {\n")
+		writeHighlightTheme(&b, darkHighlightStyle)
+		b.WriteString("}\n")
+
+		highlightCSS = b.String()
+	})
+	return highlightCSS
+}
+
+func writeHighlightTheme(b *strings.Builder, name string) {
+	style := styles.Get(name)
+	formatter := chromahtml.New(
+		chromahtml.WithClasses(true),
+		chromahtml.ClassPrefix(highlightClassPrefix),
+	)
+
+	var css strings.Builder
+	_ = formatter.WriteCSS(&css, style)
+
+	fmt.Fprintf(b, "/* Syntax highlighting: %s */\n", name)
+	b.WriteString(strings.ReplaceAll(css.String(), "*/ .", "*/ .doc-content ."))
+
+	bg := style.Get(chroma.Background)
+	fmt.Fprintf(b, ".doc-content pre.%schroma { background-color: %s; color: %s; }\n", highlightClassPrefix, bg.Background, bg.Colour)
+	fmt.Fprintf(b, ".doc-content pre.%schroma code { color: inherit; }\n", highlightClassPrefix)
+	// Lexers flag harmless trailing whitespace as errors; don't paint it red
+	fmt.Fprintf(b, ".doc-content .%[1]schroma .%[1]serr { color: inherit; background-color: transparent; }\n", highlightClassPrefix)
+}
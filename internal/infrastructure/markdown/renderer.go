@@ -0,0 +1,108 @@
+package markdown
+
+import (
+	"bytes"
+	"html/template"
+	"time"
+
+	chromahtml "github.com/alecthomas/chroma/v2/formatters/html"
+	"github.com/microcosm-cc/bluemonday"
+	"github.com/yuin/goldmark"
+	highlighting "github.com/yuin/goldmark-highlighting/v2"
+	"github.com/yuin/goldmark/extension"
+	"github.com/yuin/goldmark/parser"
+	"github.com/yuin/goldmark/renderer/html"
+	"github.com/yuin/goldmark/text"
+)
+
+// Options configures the markdown pipeline
+type Options struct {
+	// Diagrams renders ```mermaid and ```plantuml fences as SVG; when nil
+	// they are shown as code
+	Diagrams *DiagramRenderer
+	// TOCDepth is how many heading levels, starting at h2, the table of
+	// contents lists
+	TOCDepth int
+	// OnRender, when set, is called after every Render with how long it took
+	OnRender func(duration time.Duration, err error)
+}
+
+// DefaultOptions lists h2 and h3 in the table of contents and renders no diagrams
+func DefaultOptions() Options {
+	return Options{TOCDepth: 2}
+}
+
+// Renderer converts document markdown into HTML
+type Renderer struct {
+	md       goldmark.Markdown
+	sanitize *bluemonday.Policy
+	tocDepth int
+	onRender func(time.Duration, error)
+}
+
+// Rendered is a document converted to HTML
+type Rendered struct {
+	HTML template.HTML
+	TOC  []*TOCEntry
+}
+
+// NewRenderer creates the markdown pipeline used for documents.
+// Fenced code blocks are highlighted with CSS classes (see HighlightCSS) and
+// accept attributes after the language, e.g. ```go {linenos=table hl_lines=[2,"4-5"]}.
+// Embeds are written as shortcodes, e.g. {{< youtube dQw4w9WgXcQ >}}, and
+// callouts as GitHub alerts (> [!NOTE]) or containers (:::warning ... :::).
+// Headings get permalink anchors.
+func NewRenderer(opts Options) *Renderer {
+	extensions := []goldmark.Extender{
+		extension.GFM,
+		extension.Table,
+		extension.Strikethrough,
+		extension.TaskList,
+		highlighting.NewHighlighting(
+			highlighting.WithFormatOptions(
+				chromahtml.WithClasses(true),
+				chromahtml.ClassPrefix(highlightClassPrefix),
+			),
+		),
+		Shortcodes,
+		Admonitions,
+		HeadingAnchors,
+	}
+	if opts.Diagrams != nil {
+		extensions = append(extensions, Diagrams(opts.Diagrams))
+	}
+
+	md := goldmark.New(
+		goldmark.WithExtensions(extensions...),
+		goldmark.WithParserOptions(
+			parser.WithAutoHeadingID(),
+		),
+		goldmark.WithRendererOptions(
+			html.WithHardWraps(),
+			html.WithXHTML(),
+			html.WithUnsafe(), // raw HTML is passed through and then filtered by the sanitizer
+		),
+	)
+
+	return &Renderer{md: md, sanitize: newSanitizer(), tocDepth: opts.TOCDepth, onRender: opts.OnRender}
+}
+
+// Render converts source into sanitized HTML and its table of contents
+func (r *Renderer) Render(source []byte) (rendered Rendered, err error) {
+	if r.onRender != nil {
+		start := time.Now()
+		defer func() { r.onRender(time.Since(start), err) }()
+	}
+
+	doc := r.md.Parser().Parse(text.NewReader(source))
+
+	var buf bytes.Buffer
+	if err := r.md.Renderer().Render(&buf, source, doc); err != nil {
+		return Rendered{}, err
+	}
+
+	return Rendered{
+		HTML: template.HTML(r.sanitize.SanitizeBytes(buf.Bytes())),
+		TOC:  tableOfContents(doc, source, r.tocDepth),
+	}, nil
+}
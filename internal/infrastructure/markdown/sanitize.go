@@ -0,0 +1,121 @@
+package markdown
+
+import (
+	"regexp"
+
+	"github.com/microcosm-cc/bluemonday"
+)
+
+var (
+	headingID      = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+	highlightClass = regexp.MustCompile(`^hl-[a-z0-9-]+( hl-[a-z0-9-]+)*$`)
+	languageClass  = regexp.MustCompile(`^language-[A-Za-z0-9_+#-]+$`)
+	cellAlign      = regexp.MustCompile(`^(left|center|right)$`)
+	numeric        = regexp.MustCompile(`^[0-9]+%?$`)
+
+	// embedSource lists the only players allowed inside an <iframe>
+	embedSource = regexp.MustCompile(`^https://(www\.youtube\.com/embed/|www\.youtube-nocookie\.com/embed/|player\.vimeo\.com/video/)[A-Za-z0-9_-]+(\?[A-Za-z0-9_=&;%.-]*)?$`)
+	iframeAllow = regexp.MustCompile(`^[a-z-]+( *; *[a-z-]+)* *;?$`)
+
+	// Shortcode facades (see shortcode.go); the player itself is created by
+	// the view script when the reader clicks
+	embedClass = regexp.MustCompile(`^embed(-[a-z]+)?( embed-[a-z]+)*$`)
+	embedKind  = regexp.MustCompile(`^(youtube|gist|asciinema)$`)
+	embedID    = regexp.MustCompile(`^[A-Za-z0-9_-]+(/[0-9a-f]+)?$`)
+
+	admonitionClass = regexp.MustCompile(`^admonition(-[a-z]+)?( admonition-[a-z]+)*$`)
+	diagramClass    = regexp.MustCompile(`^diagram(-[a-z]+)?( diagram-[a-z]+)*$`)
+
+	// Inline SVG diagrams (see diagram.go). Names are lower case because the
+	// HTML tokenizer lowercases them; browsers restore the SVG casing.
+	svgElements = []string{
+		"svg", "g", "defs", "marker", "title", "desc",
+		"path", "rect", "circle", "ellipse", "line", "polyline", "polygon",
+		"text", "tspan", "foreignobject", "lineargradient", "radialgradient", "stop", "clippath",
+	}
+	svgName      = regexp.MustCompile(`^[A-Za-z0-9_ :.-]*$`)
+	svgNumbers   = regexp.MustCompile(`^[-+0-9.eE%, ]+$`)
+	svgPath      = regexp.MustCompile(`^[MmLlHhVvCcSsQqTtAaZz0-9eE.,+\- ]+$`)
+	svgTransform = regexp.MustCompile(`^((matrix|translate|scale|rotate|skewX|skewY)\([-+0-9.eE, ]*\) *)+$`)
+	svgPaint     = regexp.MustCompile(`^(none|currentColor|transparent|#[0-9a-fA-F]{3,8}|[a-zA-Z]+|rgba?\([0-9., %]+\)|url\(#[A-Za-z0-9_:.-]+\))$`)
+	svgReference = regexp.MustCompile(`^(none|url\(#[A-Za-z0-9_:.-]+\))$`)
+	svgKeyword   = regexp.MustCompile(`^[A-Za-z -]+$`)
+	svgFont      = regexp.MustCompile(`^[A-Za-z0-9 ,.'"-]+$`)
+)
+
+// newSanitizer returns the allowlist applied to every rendered document.
+// Documents may come from the gRPC service, so raw HTML in markdown is only
+// trusted as far as this policy goes: safe formatting, our own highlighting
+// classes, shortcode facades, admonitions, diagrams drawn as inline SVG,
+// and video players from known hosts. Scripts, event handlers,
+// styles, forms and every other iframe are removed.
+func newSanitizer() *bluemonday.Policy {
+	p := bluemonday.UGCPolicy()
+
+	// Links between our own documents don't need nofollow
+	p.RequireNoFollowOnLinks(false)
+	p.RequireNoFollowOnFullyQualifiedLinks(false)
+
+	p.AllowAttrs("id").Matching(headingID).OnElements("h1", "h2", "h3", "h4", "h5", "h6")
+	p.AllowAttrs("class").Matching(regexp.MustCompile(`^heading-anchor$`)).OnElements("a")
+	p.AllowAttrs("aria-hidden").Matching(regexp.MustCompile(`^true$`)).OnElements("a")
+	p.AllowAttrs("class").Matching(highlightClass).OnElements("div", "pre", "code", "span", "table", "tr", "td")
+	p.AllowAttrs("class").Matching(languageClass).OnElements("code")
+	p.AllowAttrs("align").Matching(cellAlign).OnElements("th", "td")
+	p.AllowAttrs("loading").Matching(regexp.MustCompile(`^(lazy|eager)$`)).OnElements("img", "iframe")
+
+	// GFM task lists
+	p.AllowAttrs("type").Matching(regexp.MustCompile(`^checkbox$`)).OnElements("input")
+	p.AllowAttrs("checked", "disabled").OnElements("input")
+
+	p.AllowElements("details", "summary")
+	p.AllowAttrs("open").OnElements("details")
+
+	p.AllowAttrs("class").Matching(embedClass).OnElements("div", "a", "img", "span", "p")
+	p.AllowAttrs("data-embed-kind").Matching(embedKind).OnElements("div")
+	p.AllowAttrs("data-embed-id").Matching(embedID).OnElements("div")
+
+	p.AllowElements("aside")
+	p.AllowAttrs("class").Matching(admonitionClass).OnElements("aside", "p")
+	p.AllowAttrs("role").Matching(regexp.MustCompile(`^note$`)).OnElements("aside")
+
+	p.AllowAttrs("class").Matching(diagramClass).OnElements("figure", "pre")
+	allowSVG(p)
+
+	p.AllowAttrs("src").Matching(embedSource).OnElements("iframe")
+	p.AllowAttrs("width", "height").Matching(numeric).OnElements("iframe")
+	p.AllowAttrs("frameborder").Matching(regexp.MustCompile(`^0$`)).OnElements("iframe")
+	p.AllowAttrs("allow").Matching(iframeAllow).OnElements("iframe")
+	p.AllowAttrs("allowfullscreen").OnElements("iframe")
+	p.AllowAttrs("title").OnElements("iframe")
+	p.AllowAttrs("referrerpolicy").Matching(regexp.MustCompile(`^[a-z-]+$`)).OnElements("iframe")
+
+	return p
+}
+
+// allowSVG lets diagrams through as drawings only: shapes, text and their
+// presentation attributes. Links, <use>, <image>, <style>, <script> and event
+// handlers are dropped, and url() may only point inside the document.
+func allowSVG(p *bluemonday.Policy) {
+	p.AllowElements(svgElements...)
+
+	p.AllowAttrs("id", "class").Matching(svgName).OnElements(svgElements...)
+	p.AllowAttrs("role", "aria-roledescription", "aria-labelledby", "aria-describedby").Matching(svgName).OnElements("svg")
+	p.AllowAttrs("viewbox", "width", "height", "x", "y", "x1", "y1", "x2", "y2", "cx", "cy", "r", "rx", "ry", "dx", "dy",
+		"points", "offset", "opacity", "fill-opacity", "stroke-opacity", "stroke-width", "stroke-dasharray",
+		"stroke-miterlimit", "font-size", "textlength", "refx", "refy", "markerwidth", "markerheight").Matching(svgNumbers).OnElements(svgElements...)
+	p.AllowAttrs("d").Matching(svgPath).OnElements("path")
+	p.AllowAttrs("transform", "gradienttransform").Matching(svgTransform).OnElements(svgElements...)
+	p.AllowAttrs("fill", "stroke", "stop-color").Matching(svgPaint).OnElements(svgElements...)
+	p.AllowAttrs("marker-start", "marker-mid", "marker-end", "clip-path").Matching(svgReference).OnElements(svgElements...)
+	p.AllowAttrs("text-anchor", "dominant-baseline", "alignment-baseline", "font-weight", "font-style", "stroke-linecap",
+		"stroke-linejoin", "orient", "markerunits", "gradientunits", "clippathunits", "preserveaspectratio",
+		"lengthadjust", "fill-rule", "clip-rule").Matching(svgKeyword).OnElements(svgElements...)
+	p.AllowAttrs("font-family").Matching(svgFont).OnElements(svgElements...)
+
+	// PlantUML draws its strokes with style attributes
+	p.AllowStyles("fill", "stroke", "stop-color").Matching(svgPaint).OnElements(svgElements...)
+	p.AllowStyles("stroke-width", "stroke-dasharray", "opacity", "fill-opacity", "stroke-opacity", "font-size", "max-width").Matching(svgNumbers).OnElements(svgElements...)
+	p.AllowStyles("font-family").Matching(svgFont).OnElements(svgElements...)
+	p.AllowStyles("font-weight", "font-style", "text-anchor").Matching(svgKeyword).OnElements(svgElements...)
+}
@@ -0,0 +1,168 @@
+package markdown
+
+import "testing"
+
+func TestSanitizerRemovesMaliciousHTML(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{
+			name:  "script element",
+			input: `<p>hi</p><script>alert(1)</script>`,
+			want:  `<p>hi</p>`,
+		},
+		{
+			name:  "script inside allowed element",
+			input: `<div><script src="https://evil.example/x.js"></script>text</div>`,
+			want:  `<div>text</div>`,
+		},
+		{
+			name:  "onerror handler",
+			input: `<img src="/cover.png" onerror="alert(1)" alt="cover">`,
+			want:  `<img src="/cover.png" alt="cover">`,
+		},
+		{
+			name:  "onclick handler",
+			input: `<a href="/docs/x" onclick="steal()">x</a>`,
+			want:  `<a href="/docs/x">x</a>`,
+		},
+		{
+			name:  "javascript href",
+			input: `<a href="javascript:alert(1)">click</a>`,
+			want:  `click`,
+		},
+		{
+			name:  "javascript href with entities",
+			input: `<a href="jav&#x09;ascript:alert(1)">click</a>`,
+			want:  `click`,
+		},
+		{
+			name:  "iframe from a host that is not allowlisted",
+			input: `<iframe src="https://evil.example/embed/abc"></iframe>`,
+			want:  ``,
+		},
+		{
+			name:  "youtube iframe on a lookalike host",
+			input: `<iframe src="https://www.youtube.com.evil.example/embed/abc"></iframe>`,
+			want:  ``,
+		},
+		{
+			name:  "iframe srcdoc",
+			input: `<iframe srcdoc="<script>alert(1)</script>" src="https://www.youtube-nocookie.com/embed/abc"></iframe>`,
+			want:  `<iframe src="https://www.youtube-nocookie.com/embed/abc"></iframe>`,
+		},
+		{
+			name:  "data text/html link",
+			input: `<a href="data:text/html;base64,PHNjcmlwdD5hbGVydCgxKTwvc2NyaXB0Pg==">x</a>`,
+			want:  `x`,
+		},
+		{
+			name:  "data text/html iframe",
+			input: `<iframe src="data:text/html,<script>alert(1)</script>"></iframe>`,
+			want:  ``,
+		},
+		{
+			name:  "data text/html image",
+			input: `<img src="data:text/html,<script>alert(1)</script>">`,
+			want:  ``,
+		},
+		{
+			name:  "form",
+			input: `<form action="https://evil.example/login" method="post"><input type="password" name="p"><button>Log in</button></form>`,
+			want:  `Log in`,
+		},
+		{
+			name:  "inline style",
+			input: `<p style="position:fixed;top:0;left:0;width:100%">overlay</p>`,
+			want:  `<p>overlay</p>`,
+		},
+		{
+			name:  "style element",
+			input: `<style>body{display:none}</style><p>text</p>`,
+			want:  `<p>text</p>`,
+		},
+		{
+			name:  "class outside the allowlist",
+			input: `<div class="navbar">x</div>`,
+			want:  `<div>x</div>`,
+		},
+		{
+			name:  "script inside svg",
+			input: `<svg viewbox="0 0 10 10"><script>alert(1)</script><rect width="10" height="10"></rect></svg>`,
+			want:  `<svg viewbox="0 0 10 10"><rect width="10" height="10"></rect></svg>`,
+		},
+		{
+			name:  "svg onload",
+			input: `<svg onload="alert(1)"><circle r="5" onmouseover="alert(1)"></circle></svg>`,
+			want:  `<svg><circle r="5"></circle></svg>`,
+		},
+		{
+			name:  "svg use of an external document",
+			input: `<svg><use href="https://evil.example/x.svg#a"></use><use xlink:href="data:image/svg+xml,x"></use></svg>`,
+			want:  `<svg></svg>`,
+		},
+		{
+			name:  "svg style element",
+			input: `<svg><style>@import url(https://evil.example/x.css);</style><text x="1">label</text></svg>`,
+			want:  `<svg><text x="1">label</text></svg>`,
+		},
+		{
+			name:  "svg paint from another document",
+			input: `<svg><path d="M0 0L10 10" fill="url(https://evil.example/x.svg#g)" stroke="url(#arrow)"></path></svg>`,
+			want:  `<svg><path d="M0 0L10 10" stroke="url(#arrow)"></path></svg>`,
+		},
+		{
+			name:  "svg anchor",
+			input: `<svg><a href="javascript:alert(1)"><text>x</text></a></svg>`,
+			want:  `<svg>x</svg>`,
+		},
+	}
+
+	sanitize := newSanitizer()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := sanitize.Sanitize(tt.input); got != tt.want {
+				t.Errorf("Sanitize(%q)\n got: %q\nwant: %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSanitizerKeepsAllowedHTML(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+	}{
+		{
+			name:  "allowlisted player",
+			input: `<iframe src="https://www.youtube-nocookie.com/embed/dQw4w9WgXcQ" width="560" height="315" allowfullscreen=""></iframe>`,
+		},
+		{
+			name:  "highlighted code",
+			input: `<pre class="hl-chroma"><code class="language-go"><span class="hl-kd">func</span></code></pre>`,
+		},
+		{
+			name:  "heading anchor",
+			input: `<h2 id="setup">Setup<a class="heading-anchor" href="#setup" aria-hidden="true">#</a></h2>`,
+		},
+		{
+			name:  "admonition",
+			input: `<aside class="admonition admonition-warning" role="note"><p class="admonition-title">Warning</p></aside>`,
+		},
+		{
+			name:  "diagram",
+			input: `<figure class="diagram diagram-mermaid"><svg id="mermaid-1" viewbox="0 0 100 40" role="graphics-document document"><g class="node"><rect x="0" y="0" width="40" height="20" rx="4"></rect><text x="20" y="14" text-anchor="middle">API</text></g><path d="M40,10L60,10" marker-end="url(#arrow)" style="stroke-width: 2"></path></svg></figure>`,
+		},
+	}
+
+	sanitize := newSanitizer()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := sanitize.Sanitize(tt.input); got != tt.input {
+				t.Errorf("Sanitize(%q)\n got: %q\nwant it unchanged", tt.input, got)
+			}
+		})
+	}
+}
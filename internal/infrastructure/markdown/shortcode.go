@@ -0,0 +1,153 @@
+package markdown
+
+import (
+	"fmt"
+	"html"
+	"regexp"
+	"strings"
+
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/parser"
+	"github.com/yuin/goldmark/renderer"
+	"github.com/yuin/goldmark/text"
+	"github.com/yuin/goldmark/util"
+)
+
+// KindShortcode is the node kind of a Shortcode
+var KindShortcode = ast.NewNodeKind("Shortcode")
+
+// Shortcode is a block written as {{< name args... >}} on a line of its own
+type Shortcode struct {
+	ast.BaseBlock
+	Name string
+	Args []string
+}
+
+// Kind implements ast.Node
+func (n *Shortcode) Kind() ast.NodeKind {
+	return KindShortcode
+}
+
+// Dump implements ast.Node
+func (n *Shortcode) Dump(source []byte, level int) {
+	ast.DumpHelper(n, source, level, map[string]string{
+		"Name": n.Name,
+		"Args": strings.Join(n.Args, " "),
+	}, nil)
+}
+
+var shortcodeLine = regexp.MustCompile(`^\{\{<\s*([a-z]+)((?:\s+[^\s>]+)*)\s*>\}\}$`)
+
+// embed describes how a shortcode turns into a click-to-load embed
+type embed struct {
+	validID *regexp.Regexp
+	// link is where the facade points, so the embed still works without JavaScript
+	link func(id string) string
+	// thumbnail is an optional preview image for the facade
+	thumbnail func(id string) string
+	label     string
+}
+
+var embeds = map[string]embed{
+	"youtube": {
+		validID:   regexp.MustCompile(`^[A-Za-z0-9_-]{6,20}$`),
+		link:      func(id string) string { return "https://www.youtube.com/watch?v=" + id },
+		thumbnail: func(id string) string { return "https://i.ytimg.com/vi/" + id + "/hqdefault.jpg" },
+		label:     "Play video",
+	},
+	"gist": {
+		validID: regexp.MustCompile(`^[A-Za-z0-9-]{1,39}/[0-9a-f]{6,64}$`),
+		link:    func(id string) string { return "https://gist.github.com/" + id },
+		label:   "Load gist",
+	},
+	"asciinema": {
+		validID:   regexp.MustCompile(`^[A-Za-z0-9]{1,32}$`),
+		link:      func(id string) string { return "https://asciinema.org/a/" + id },
+		thumbnail: func(id string) string { return "https://asciinema.org/a/" + id + ".svg" },
+		label:     "Play recording",
+	},
+}
+
+type shortcodeParser struct{}
+
+func (p *shortcodeParser) Trigger() []byte {
+	return []byte{'{'}
+}
+
+func (p *shortcodeParser) Open(parent ast.Node, reader text.Reader, pc parser.Context) (ast.Node, parser.State) {
+	line, segment := reader.PeekLine()
+	m := shortcodeLine.FindSubmatch(util.TrimRightSpace(util.TrimLeftSpace(line)))
+	if m == nil {
+		return nil, parser.NoChildren
+	}
+	name := string(m[1])
+	if _, ok := embeds[name]; !ok {
+		return nil, parser.NoChildren
+	}
+
+	reader.Advance(segment.Len() - 1)
+	return &Shortcode{Name: name, Args: strings.Fields(string(m[2]))}, parser.NoChildren
+}
+
+func (p *shortcodeParser) Continue(node ast.Node, reader text.Reader, pc parser.Context) parser.State {
+	return parser.Close
+}
+
+func (p *shortcodeParser) Close(node ast.Node, reader text.Reader, pc parser.Context) {}
+
+func (p *shortcodeParser) CanInterruptParagraph() bool {
+	return true
+}
+
+func (p *shortcodeParser) CanAcceptIndentedLine() bool {
+	return false
+}
+
+type shortcodeRenderer struct{}
+
+func (r *shortcodeRenderer) RegisterFuncs(reg renderer.NodeRendererFuncRegisterer) {
+	reg.Register(KindShortcode, r.render)
+}
+
+// render writes a privacy-friendly facade: nothing is loaded from the
+// third party until the reader clicks it (see docs/view/script.js)
+func (r *shortcodeRenderer) render(w util.BufWriter, source []byte, node ast.Node, entering bool) (ast.WalkStatus, error) {
+	if !entering {
+		return ast.WalkContinue, nil
+	}
+
+	n := node.(*Shortcode)
+	e := embeds[n.Name]
+	if len(n.Args) != 1 || !e.validID.MatchString(n.Args[0]) {
+		fmt.Fprintf(w, "<p class=\"embed-error\">Invalid %s shortcode: expected {{&lt; %s id &gt;}}</p>\n", n.Name, n.Name)
+		return ast.WalkSkipChildren, nil
+	}
+
+	id := html.EscapeString(n.Args[0])
+	link := html.EscapeString(e.link(n.Args[0]))
+
+	fmt.Fprintf(w, "<div class=\"embed embed-%s\" data-embed-kind=\"%s\" data-embed-id=\"%s\">\n", n.Name, n.Name, id)
+	fmt.Fprintf(w, "<a class=\"embed-facade\" href=\"%s\">", link)
+	if e.thumbnail != nil {
+		fmt.Fprintf(w, "<img class=\"embed-thumbnail\" src=\"%s\" alt=\"\" loading=\"lazy\"/>", html.EscapeString(e.thumbnail(n.Args[0])))
+	}
+	fmt.Fprintf(w, "<span class=\"embed-play\">%s</span></a>\n</div>\n", e.label)
+
+	return ast.WalkSkipChildren, nil
+}
+
+type shortcodes struct{}
+
+// Shortcodes is a goldmark extension for {{< youtube id >}},
+// {{< gist user/id >}} and {{< asciinema id >}} embeds
+var Shortcodes goldmark.Extender = &shortcodes{}
+
+func (e *shortcodes) Extend(m goldmark.Markdown) {
+	m.Parser().AddOptions(parser.WithBlockParsers(
+		util.Prioritized(&shortcodeParser{}, 150),
+	))
+	m.Renderer().AddOptions(renderer.WithNodeRenderers(
+		util.Prioritized(&shortcodeRenderer{}, 500),
+	))
+}
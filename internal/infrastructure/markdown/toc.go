@@ -0,0 +1,102 @@
+package markdown
+
+import (
+	"markitos-it-app-website/internal/mdtext"
+
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/renderer"
+	"github.com/yuin/goldmark/renderer/html"
+	"github.com/yuin/goldmark/util"
+)
+
+// tocMinLevel is the first heading level listed in the table of contents;
+// h1 is the document title
+const tocMinLevel = 2
+
+// TOCEntry is a heading in the table of contents
+type TOCEntry struct {
+	ID       string
+	Title    string
+	Level    int
+	Children []*TOCEntry
+}
+
+// tableOfContents nests the headings of doc from h2 down to depth levels,
+// using the same IDs the renderer writes into the HTML
+func tableOfContents(doc ast.Node, source []byte, depth int) []*TOCEntry {
+	maxLevel := tocMinLevel + depth - 1
+
+	root := &TOCEntry{}
+	stack := []*TOCEntry{root}
+
+	_ = ast.Walk(doc, func(n ast.Node, entering bool) (ast.WalkStatus, error) {
+		heading, ok := n.(*ast.Heading)
+		if !ok || !entering {
+			return ast.WalkContinue, nil
+		}
+		if heading.Level < tocMinLevel || heading.Level > maxLevel {
+			return ast.WalkSkipChildren, nil
+		}
+		id, ok := heading.AttributeString("id")
+		if !ok {
+			return ast.WalkSkipChildren, nil
+		}
+
+		entry := &TOCEntry{ID: string(id.([]byte)), Title: mdtext.PlainText(heading, source), Level: heading.Level}
+		for len(stack) > 1 && stack[len(stack)-1].Level >= entry.Level {
+			stack = stack[:len(stack)-1]
+		}
+		parent := stack[len(stack)-1]
+		parent.Children = append(parent.Children, entry)
+		stack = append(stack, entry)
+
+		return ast.WalkSkipChildren, nil
+	})
+
+	return root.Children
+}
+
+// headingRenderer writes headings like goldmark does, plus a permalink
+// anchor that shows up on hover
+type headingRenderer struct{}
+
+func (r *headingRenderer) RegisterFuncs(reg renderer.NodeRendererFuncRegisterer) {
+	reg.Register(ast.KindHeading, r.render)
+}
+
+func (r *headingRenderer) render(w util.BufWriter, source []byte, node ast.Node, entering bool) (ast.WalkStatus, error) {
+	n := node.(*ast.Heading)
+	level := "0123456"[n.Level]
+
+	if entering {
+		_, _ = w.WriteString("<h")
+		_ = w.WriteByte(level)
+		if n.Attributes() != nil {
+			html.RenderAttributes(w, node, html.HeadingAttributeFilter)
+		}
+		_ = w.WriteByte('>')
+		return ast.WalkContinue, nil
+	}
+
+	if id, ok := n.AttributeString("id"); ok {
+		_, _ = w.WriteString(`<a class="heading-anchor" href="#`)
+		_, _ = w.Write(util.EscapeHTML(id.([]byte)))
+		_, _ = w.WriteString(`" aria-hidden="true">#</a>`)
+	}
+	_, _ = w.WriteString("</h")
+	_ = w.WriteByte(level)
+	_, _ = w.WriteString(">\n")
+	return ast.WalkContinue, nil
+}
+
+type headingAnchors struct{}
+
+// HeadingAnchors is a goldmark extension that adds permalink anchors to headings
+var HeadingAnchors goldmark.Extender = &headingAnchors{}
+
+func (e *headingAnchors) Extend(m goldmark.Markdown) {
+	m.Renderer().AddOptions(renderer.WithNodeRenderers(
+		util.Prioritized(&headingRenderer{}, 500),
+	))
+}
@@ -0,0 +1,233 @@
+package metrics
+
+import (
+	"context"
+	"net/http"
+	"runtime/debug"
+	"strconv"
+	"time"
+
+	"markitos-it-app-website/internal/domain/documents"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+const namespace = "markitos"
+
+// Metrics holds the Prometheus collectors of the app on their own registry,
+// exposed by Handler
+type Metrics struct {
+	registry *prometheus.Registry
+
+	httpRequests *prometheus.CounterVec
+	httpDuration *prometheus.HistogramVec
+	grpcRequests *prometheus.CounterVec
+	grpcDuration *prometheus.HistogramVec
+	fallbacks    *prometheus.CounterVec
+	cacheLookups *prometheus.CounterVec
+	renders      *prometheus.CounterVec
+	renderTime   prometheus.Histogram
+}
+
+// New creates and registers every collector, along with the Go runtime,
+// process and build info collectors
+func New() *Metrics {
+	m := &Metrics{
+		registry: prometheus.NewRegistry(),
+		httpRequests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "http_requests_total",
+			Help:      "HTTP requests by route pattern, method and status code.",
+		}, []string{"route", "method", "code"}),
+		httpDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "http_request_duration_seconds",
+			Help:      "HTTP request latency by route pattern and method.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"route", "method"}),
+		grpcRequests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "grpc_client_requests_total",
+			Help:      "Documents service call attempts by method and gRPC status code.",
+		}, []string{"method", "code"}),
+		grpcDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "grpc_client_request_duration_seconds",
+			Help:      "Documents service call attempt latency by method.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"method"}),
+		fallbacks: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "docs_fallback_total",
+			Help:      "Repository calls answered by the embedded documents because the documents service was unavailable.",
+		}, []string{"operation"}),
+		cacheLookups: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "docs_cache_lookups_total",
+			Help:      "Documents cache lookups by entry kind and result (hit, stale or miss).",
+		}, []string{"kind", "result"}),
+		renders: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "markdown_renders_total",
+			Help:      "Markdown renders by result (ok or error).",
+		}, []string{"result"}),
+		renderTime: prometheus.NewHistogram(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "markdown_render_duration_seconds",
+			Help:      "Time to render a document to HTML, diagrams included.",
+			Buckets:   []float64{.001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10},
+		}),
+	}
+
+	m.registry.MustRegister(
+		m.httpRequests, m.httpDuration,
+		m.grpcRequests, m.grpcDuration,
+		m.fallbacks, m.cacheLookups,
+		m.renders, m.renderTime,
+		buildInfo(),
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+	)
+
+	return m
+}
+
+// buildInfo is a constant 1 labelled with the version and VCS revision of the binary
+func buildInfo() prometheus.Collector {
+	version, revision, goVersion := "unknown", "unknown", "unknown"
+	if info, ok := debug.ReadBuildInfo(); ok {
+		version, goVersion = info.Main.Version, info.GoVersion
+		for _, setting := range info.Settings {
+			if setting.Key == "vcs.revision" {
+				revision = setting.Value
+			}
+		}
+	}
+
+	gauge := prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace:   namespace,
+		Name:        "build_info",
+		Help:        "Build information of the running binary; always 1.",
+		ConstLabels: prometheus.Labels{"version": version, "revision": revision, "goversion": goVersion},
+	})
+	gauge.Set(1)
+	return gauge
+}
+
+// Handler serves the metrics in the Prometheus exposition format
+func (m *Metrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{Registry: m.registry})
+}
+
+// Middleware records every request handled by mux, labelled with the
+// pattern it matched so paths like /docs/{id} do not blow up cardinality
+func (m *Metrics) Middleware(mux *http.ServeMux) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		recorder := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+
+		mux.ServeHTTP(recorder, r)
+
+		// ServeMux sets the matched pattern on the request it was given
+		route := r.Pattern
+		if route == "" {
+			route = "unmatched"
+		}
+		method := methodLabel(r.Method)
+		m.httpRequests.WithLabelValues(route, method, strconv.Itoa(recorder.status)).Inc()
+		m.httpDuration.WithLabelValues(route, method).Observe(time.Since(start).Seconds())
+	})
+}
+
+// methodLabel keeps the standard methods and folds anything else a client
+// sends into "other", which would otherwise add a series per made-up method
+func methodLabel(method string) string {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodPost, http.MethodPut, http.MethodPatch,
+		http.MethodDelete, http.MethodConnect, http.MethodOptions, http.MethodTrace:
+		return method
+	}
+	return "other"
+}
+
+type statusRecorder struct {
+	http.ResponseWriter
+	status      int
+	wroteHeader bool
+}
+
+func (r *statusRecorder) WriteHeader(status int) {
+	if !r.wroteHeader {
+		r.status = status
+		r.wroteHeader = true
+	}
+	r.ResponseWriter.WriteHeader(status)
+}
+
+func (r *statusRecorder) Write(b []byte) (int, error) {
+	r.wroteHeader = true
+	return r.ResponseWriter.Write(b)
+}
+
+// Unwrap lets http.ResponseController reach the underlying writer
+func (r *statusRecorder) Unwrap() http.ResponseWriter {
+	return r.ResponseWriter
+}
+
+// UnaryClientInterceptor records every documents service call attempt,
+// retries included, for documents.GRPCOptions.Interceptors
+func (m *Metrics) UnaryClientInterceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		start := time.Now()
+		err := invoker(ctx, method, req, reply, cc, opts...)
+
+		m.grpcRequests.WithLabelValues(method, status.Code(err).String()).Inc()
+		m.grpcDuration.WithLabelValues(method).Observe(time.Since(start).Seconds())
+		return err
+	}
+}
+
+// ObserveCache records a documents cache lookup, for CachedRepository.OnLookup
+func (m *Metrics) ObserveCache(kind string, result documents.CacheResult) {
+	m.cacheLookups.WithLabelValues(kind, string(result)).Inc()
+}
+
+// ObserveRender records a markdown render, for markdown.Options.OnRender
+func (m *Metrics) ObserveRender(duration time.Duration, err error) {
+	result := "ok"
+	if err != nil {
+		result = "error"
+	}
+	m.renders.WithLabelValues(result).Inc()
+	m.renderTime.Observe(duration.Seconds())
+}
+
+// CountFallbacks wraps the fallback repository of a FallbackRepository: it is
+// only called when the primary is unavailable, so every call is a fallback
+func (m *Metrics) CountFallbacks(repo documents.Repository) documents.Repository {
+	return &fallbackCounter{Repository: repo, fallbacks: m.fallbacks}
+}
+
+type fallbackCounter struct {
+	documents.Repository
+	fallbacks *prometheus.CounterVec
+}
+
+func (r *fallbackCounter) List(ctx context.Context) ([]documents.Document, error) {
+	r.fallbacks.WithLabelValues("list").Inc()
+	return r.Repository.List(ctx)
+}
+
+func (r *fallbackCounter) ListSummaries(ctx context.Context, filter documents.Query) ([]documents.Document, error) {
+	r.fallbacks.WithLabelValues("list_summaries").Inc()
+	return r.Repository.ListSummaries(ctx, filter)
+}
+
+func (r *fallbackCounter) Get(ctx context.Context, id string) (*documents.Document, error) {
+	r.fallbacks.WithLabelValues("get").Inc()
+	return r.Repository.Get(ctx, id)
+}
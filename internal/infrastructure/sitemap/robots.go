@@ -0,0 +1,38 @@
+package sitemap
+
+import (
+	"fmt"
+	"strings"
+)
+
+// RobotsOptions configures robots.txt
+type RobotsOptions struct {
+	// DisallowAll keeps every crawler out, e.g. on staging
+	DisallowAll bool
+	// Disallow lists path prefixes crawlers should skip
+	Disallow []string
+}
+
+// DefaultRobotsOptions keeps crawlers out of search results, the health checks and metrics
+func DefaultRobotsOptions() RobotsOptions {
+	return RobotsOptions{Disallow: []string{"/docs/search", "/health", "/livez", "/readyz", "/startupz", "/metrics"}}
+}
+
+// WriteRobots renders a robots.txt for every user agent that points
+// crawlers at the sitemap
+func WriteRobots(opts RobotsOptions, sitemapURL string) []byte {
+	var b strings.Builder
+	b.WriteString("User-agent: *\n")
+	switch {
+	case opts.DisallowAll:
+		b.WriteString("Disallow: /\n")
+	case len(opts.Disallow) == 0:
+		b.WriteString("Disallow:\n")
+	default:
+		for _, path := range opts.Disallow {
+			fmt.Fprintf(&b, "Disallow: %s\n", path)
+		}
+	}
+	fmt.Fprintf(&b, "\nSitemap: %s\n", sitemapURL)
+	return []byte(b.String())
+}
@@ -0,0 +1,64 @@
+package sitemap
+
+import (
+	"encoding/xml"
+	"time"
+)
+
+// MaxURLs is the most URLs the sitemap protocol allows in a single file;
+// bigger sites need a sitemap index
+const MaxURLs = 50000
+
+const namespace = "http://www.sitemaps.org/schemas/sitemap/0.9"
+
+// URL is an entry of a sitemap. LastMod is left out when zero.
+type URL struct {
+	Loc     string
+	LastMod time.Time
+}
+
+type urlSet struct {
+	XMLName xml.Name   `xml:"urlset"`
+	Xmlns   string     `xml:"xmlns,attr"`
+	URLs    []location `xml:"url"`
+}
+
+type sitemapIndex struct {
+	XMLName  xml.Name   `xml:"sitemapindex"`
+	Xmlns    string     `xml:"xmlns,attr"`
+	Sitemaps []location `xml:"sitemap"`
+}
+
+type location struct {
+	Loc     string `xml:"loc"`
+	LastMod string `xml:"lastmod,omitempty"`
+}
+
+// Write renders urls as a <urlset> sitemap
+func Write(urls []URL) ([]byte, error) {
+	return marshal(urlSet{Xmlns: namespace, URLs: locations(urls)})
+}
+
+// WriteIndex renders a <sitemapindex> pointing at the given sitemaps
+func WriteIndex(sitemaps []URL) ([]byte, error) {
+	return marshal(sitemapIndex{Xmlns: namespace, Sitemaps: locations(sitemaps)})
+}
+
+func locations(urls []URL) []location {
+	out := make([]location, len(urls))
+	for i, u := range urls {
+		out[i] = location{Loc: u.Loc}
+		if !u.LastMod.IsZero() {
+			out[i].LastMod = u.LastMod.Format("2006-01-02")
+		}
+	}
+	return out
+}
+
+func marshal(v any) ([]byte, error) {
+	out, err := xml.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(xml.Header), append(out, '\n')...), nil
+}
@@ -0,0 +1,32 @@
+// Package mdtext extracts plain text from goldmark syntax trees. It is shared
+// by the renderer, for table of contents titles, and by the documents domain,
+// for outlines, so both read headings the same way.
+package mdtext
+
+import (
+	"strings"
+
+	"github.com/yuin/goldmark/ast"
+)
+
+// PlainText joins the text of the inline children of n, without the
+// emphasis, code and link markup around it. Line breaks become spaces.
+func PlainText(n ast.Node, source []byte) string {
+	var b strings.Builder
+	_ = ast.Walk(n, func(n ast.Node, entering bool) (ast.WalkStatus, error) {
+		if !entering {
+			return ast.WalkContinue, nil
+		}
+		switch n := n.(type) {
+		case *ast.Text:
+			b.Write(n.Segment.Value(source))
+			if n.SoftLineBreak() || n.HardLineBreak() {
+				b.WriteByte(' ')
+			}
+		case *ast.String:
+			b.Write(n.Value)
+		}
+		return ast.WalkContinue, nil
+	})
+	return strings.TrimSpace(b.String())
+}
@@ -0,0 +1,36 @@
+package mdtext
+
+import (
+	"testing"
+
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/extension"
+	"github.com/yuin/goldmark/text"
+)
+
+func TestPlainText(t *testing.T) {
+	parser := goldmark.New(goldmark.WithExtensions(extension.GFM)).Parser()
+
+	tests := []struct {
+		name     string
+		markdown string
+		want     string
+	}{
+		{name: "plain", markdown: "Setup", want: "Setup"},
+		{name: "emphasis and code", markdown: "The **`go`** _tool_", want: "The go tool"},
+		{name: "link", markdown: "Read [the docs](https://go.dev) first", want: "Read the docs first"},
+		{name: "strikethrough", markdown: "Old ~~way~~", want: "Old way"},
+		{name: "soft line break", markdown: "one\ntwo", want: "one two"},
+		{name: "hard line break", markdown: "one  \ntwo", want: "one two"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			source := []byte(tt.markdown)
+			doc := parser.Parse(text.NewReader(source))
+			if got := PlainText(doc.FirstChild(), source); got != tt.want {
+				t.Errorf("PlainText(%q) = %q, want %q", tt.markdown, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,7 @@
+// Keep the cursor at the end of a pre-filled query
+const searchQuery = document.getElementById('searchQuery');
+
+if (searchQuery && searchQuery.value) {
+    const length = searchQuery.value.length;
+    searchQuery.setSelectionRange(length, length);
+}
@@ -1,62 +1,19 @@
-// Generate Table of Contents
-function generateTableOfContents() {
-    const content = document.querySelector('.doc-content');
-    const tocContainer = document.getElementById('tableOfContents');
-
-    if (!content || !tocContainer) return;
-
-    const headings = content.querySelectorAll('h2, h3');
-
-    if (headings.length === 0) {
-        tocContainer.innerHTML = '<p style="color: var(--text-light); font-size: 0.85rem;">No headings found</p>';
-        return;
-    }
-
-    const usedIds = new Set();
-    const slugify = (value) => value
-        .toLowerCase()
-        .normalize('NFD').replace(/\p{Diacritic}/gu, '')
-        .replace(/[^a-z0-9\s-]/g, '')
-        .trim()
-        .replace(/\s+/g, '-')
-        .replace(/-+/g, '-');
-
-    headings.forEach((heading, index) => {
-        const level = heading.tagName.toLowerCase();
-        const text = heading.textContent.trim();
-
-        let id = heading.id;
-        if (!id) {
-            id = slugify(text) || `heading-${index}`;
-        }
-
-        let uniqueId = id;
-        let counter = 2;
-        while (usedIds.has(uniqueId)) {
-            uniqueId = `${id}-${counter}`;
-            counter += 1;
-        }
-        usedIds.add(uniqueId);
-        heading.id = uniqueId;
-
-        // Create TOC link
-        const link = document.createElement('a');
-        link.href = `#${uniqueId}`;
-        link.textContent = text;
-        link.dataset.level = level.replace('h', '');
+// Table of Contents links are rendered by the server
+function initTableOfContents() {
+    document.querySelectorAll('.doc-toc a').forEach(link => {
+        const heading = document.getElementById(decodeURIComponent(link.hash.slice(1)));
+        if (!heading) return;
 
         link.addEventListener('click', (e) => {
             e.preventDefault();
             heading.scrollIntoView({ behavior: 'smooth', block: 'start' });
 
             // Update URL without scrolling
-            history.pushState(null, null, `#${uniqueId}`);
+            history.pushState(null, null, link.hash);
 
             // Update active state
             updateActiveTocLink(link);
         });
-
-        tocContainer.appendChild(link);
     });
 }
 
@@ -70,16 +27,18 @@ function updateActiveTocLink(activeLink) {
 
 // Scroll spy for TOC
 function initScrollSpy() {
-    const headings = document.querySelectorAll('.doc-content h2, .doc-content h3');
     const tocLinks = document.querySelectorAll('.doc-toc a');
+    const headings = Array.from(tocLinks)
+        .map(link => document.getElementById(decodeURIComponent(link.hash.slice(1))))
+        .filter(Boolean);
 
-    if (headings.length === 0 || tocLinks.length === 0) return;
+    if (headings.length === 0) return;
 
     const observer = new IntersectionObserver((entries) => {
         entries.forEach(entry => {
             if (entry.isIntersecting) {
                 const id = entry.target.id;
-                const activeLink = document.querySelector(`.doc-toc a[href="#${id}"]`);
+                const activeLink = document.querySelector(`.doc-toc a[href="#${CSS.escape(id)}"]`);
                 if (activeLink) {
                     updateActiveTocLink(activeLink);
                 }
@@ -181,16 +140,55 @@ function enhanceCodeBlocks() {
     });
 }
 
+// Embeds: load the third-party player only when the reader asks for it
+const embedPlayers = {
+    youtube: (id) => ({
+        src: `https://www.youtube-nocookie.com/embed/${id}?autoplay=1`,
+        allow: 'accelerometer; autoplay; encrypted-media; gyroscope; picture-in-picture'
+    }),
+    asciinema: (id) => ({
+        src: `https://asciinema.org/a/${id}/iframe?autoplay=1`
+    }),
+    // Gists only ship as a document.write script, so it runs in a sandboxed frame
+    gist: (id) => ({
+        srcdoc: `<base target="_blank"><script src="https://gist.github.com/${id}.js"><\/script>`,
+        sandbox: 'allow-scripts allow-popups'
+    })
+};
+
+function initEmbeds() {
+    document.querySelectorAll('.doc-content .embed').forEach(embed => {
+        const facade = embed.querySelector('.embed-facade');
+        const player = embedPlayers[embed.dataset.embedKind];
+        if (!facade || !player) return;
+
+        facade.addEventListener('click', (e) => {
+            e.preventDefault();
+
+            const iframe = document.createElement('iframe');
+            Object.entries(player(embed.dataset.embedId)).forEach(([name, value]) => {
+                iframe.setAttribute(name, value);
+            });
+            iframe.title = facade.textContent.trim();
+            iframe.referrerPolicy = 'strict-origin-when-cross-origin';
+            iframe.allowFullscreen = true;
+
+            embed.replaceChildren(iframe);
+        });
+    });
+}
+
 // Initialize on page load
 document.addEventListener('DOMContentLoaded', () => {
-    generateTableOfContents();
+    initTableOfContents();
     initScrollSpy();
     enhanceCodeBlocks();
+    initEmbeds();
 
     // Handle initial hash
     if (window.location.hash) {
         setTimeout(() => {
-            const target = document.querySelector(window.location.hash);
+            const target = document.getElementById(decodeURIComponent(window.location.hash.slice(1)));
             if (target) {
                 target.scrollIntoView({ behavior: 'smooth' });
             }
@@ -22,17 +22,127 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+// Vista de los documentos devueltos por ListDocuments
+type DocumentView int32
+
+const (
+	// Equivale a DOCUMENT_VIEW_SUMMARY
+	DocumentView_DOCUMENT_VIEW_UNSPECIFIED DocumentView = 0
+	// Solo metadatos, sin content_b64
+	DocumentView_DOCUMENT_VIEW_SUMMARY DocumentView = 1
+	// Metadatos y contenido completo
+	DocumentView_DOCUMENT_VIEW_FULL DocumentView = 2
+)
+
+// Enum value maps for DocumentView.
+var (
+	DocumentView_name = map[int32]string{
+		0: "DOCUMENT_VIEW_UNSPECIFIED",
+		1: "DOCUMENT_VIEW_SUMMARY",
+		2: "DOCUMENT_VIEW_FULL",
+	}
+	DocumentView_value = map[string]int32{
+		"DOCUMENT_VIEW_UNSPECIFIED": 0,
+		"DOCUMENT_VIEW_SUMMARY":     1,
+		"DOCUMENT_VIEW_FULL":        2,
+	}
+)
+
+func (x DocumentView) Enum() *DocumentView {
+	p := new(DocumentView)
+	*p = x
+	return p
+}
+
+func (x DocumentView) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (DocumentView) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_documents_proto_enumTypes[0].Descriptor()
+}
+
+func (DocumentView) Type() protoreflect.EnumType {
+	return &file_proto_documents_proto_enumTypes[0]
+}
+
+func (x DocumentView) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use DocumentView.Descriptor instead.
+func (DocumentView) EnumDescriptor() ([]byte, []int) {
+	return file_proto_documents_proto_rawDescGZIP(), []int{0}
+}
+
+// Orden de los documentos devueltos por ListDocuments
+type DocumentOrder int32
+
+const (
+	// Equivale a DOCUMENT_ORDER_UPDATED_DESC
+	DocumentOrder_DOCUMENT_ORDER_UNSPECIFIED DocumentOrder = 0
+	// Los actualizados más recientemente primero
+	DocumentOrder_DOCUMENT_ORDER_UPDATED_DESC DocumentOrder = 1
+	// Alfabéticamente por título
+	DocumentOrder_DOCUMENT_ORDER_TITLE_ASC DocumentOrder = 2
+)
+
+// Enum value maps for DocumentOrder.
+var (
+	DocumentOrder_name = map[int32]string{
+		0: "DOCUMENT_ORDER_UNSPECIFIED",
+		1: "DOCUMENT_ORDER_UPDATED_DESC",
+		2: "DOCUMENT_ORDER_TITLE_ASC",
+	}
+	DocumentOrder_value = map[string]int32{
+		"DOCUMENT_ORDER_UNSPECIFIED":  0,
+		"DOCUMENT_ORDER_UPDATED_DESC": 1,
+		"DOCUMENT_ORDER_TITLE_ASC":    2,
+	}
+)
+
+func (x DocumentOrder) Enum() *DocumentOrder {
+	p := new(DocumentOrder)
+	*p = x
+	return p
+}
+
+func (x DocumentOrder) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (DocumentOrder) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_documents_proto_enumTypes[1].Descriptor()
+}
+
+func (DocumentOrder) Type() protoreflect.EnumType {
+	return &file_proto_documents_proto_enumTypes[1]
+}
+
+func (x DocumentOrder) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use DocumentOrder.Descriptor instead.
+func (DocumentOrder) EnumDescriptor() ([]byte, []int) {
+	return file_proto_documents_proto_rawDescGZIP(), []int{1}
+}
+
 // Document representa un documento en el sistema
 type Document struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Title         string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
-	Description   string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
-	Category      string                 `protobuf:"bytes,4,opt,name=category,proto3" json:"category,omitempty"`
-	Tags          []string               `protobuf:"bytes,5,rep,name=tags,proto3" json:"tags,omitempty"`
-	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
-	ContentB64    string                 `protobuf:"bytes,7,opt,name=content_b64,json=contentB64,proto3" json:"content_b64,omitempty"`
-	CoverImage    string                 `protobuf:"bytes,8,opt,name=cover_image,json=coverImage,proto3" json:"cover_image,omitempty"`
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Id          string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Title       string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	Description string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	Category    string                 `protobuf:"bytes,4,opt,name=category,proto3" json:"category,omitempty"`
+	Tags        []string               `protobuf:"bytes,5,rep,name=tags,proto3" json:"tags,omitempty"`
+	UpdatedAt   *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	ContentB64  string                 `protobuf:"bytes,7,opt,name=content_b64,json=contentB64,proto3" json:"content_b64,omitempty"`
+	CoverImage  string                 `protobuf:"bytes,8,opt,name=cover_image,json=coverImage,proto3" json:"cover_image,omitempty"`
+	// Estadísticas del contenido, calculadas por el servidor para que la vista
+	// DOCUMENT_VIEW_SUMMARY las incluya sin content_b64. Ausente en servidores
+	// que aún no las calculan.
+	Stats         *DocumentStats `protobuf:"bytes,9,opt,name=stats,proto3" json:"stats,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -123,6 +233,85 @@ func (x *Document) GetCoverImage() string {
 	return ""
 }
 
+func (x *Document) GetStats() *DocumentStats {
+	if x != nil {
+		return x.Stats
+	}
+	return nil
+}
+
+// DocumentStats resume el contenido markdown de un documento
+type DocumentStats struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Palabras fuera de los bloques de código
+	Words int32 `protobuf:"varint,1,opt,name=words,proto3" json:"words,omitempty"`
+	// Minutos de lectura estimados, prosa y código incluidos
+	ReadingMinutes int32 `protobuf:"varint,2,opt,name=reading_minutes,json=readingMinutes,proto3" json:"reading_minutes,omitempty"`
+	CodeBlocks     int32 `protobuf:"varint,3,opt,name=code_blocks,json=codeBlocks,proto3" json:"code_blocks,omitempty"`
+	// Secciones de primer nivel (h2)
+	Sections      int32 `protobuf:"varint,4,opt,name=sections,proto3" json:"sections,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DocumentStats) Reset() {
+	*x = DocumentStats{}
+	mi := &file_proto_documents_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DocumentStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DocumentStats) ProtoMessage() {}
+
+func (x *DocumentStats) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_documents_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DocumentStats.ProtoReflect.Descriptor instead.
+func (*DocumentStats) Descriptor() ([]byte, []int) {
+	return file_proto_documents_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *DocumentStats) GetWords() int32 {
+	if x != nil {
+		return x.Words
+	}
+	return 0
+}
+
+func (x *DocumentStats) GetReadingMinutes() int32 {
+	if x != nil {
+		return x.ReadingMinutes
+	}
+	return 0
+}
+
+func (x *DocumentStats) GetCodeBlocks() int32 {
+	if x != nil {
+		return x.CodeBlocks
+	}
+	return 0
+}
+
+func (x *DocumentStats) GetSections() int32 {
+	if x != nil {
+		return x.Sections
+	}
+	return 0
+}
+
 // Request para obtener todos los documentos
 type GetAllDocumentsRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -132,7 +321,7 @@ type GetAllDocumentsRequest struct {
 
 func (x *GetAllDocumentsRequest) Reset() {
 	*x = GetAllDocumentsRequest{}
-	mi := &file_proto_documents_proto_msgTypes[1]
+	mi := &file_proto_documents_proto_msgTypes[2]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -144,7 +333,7 @@ func (x *GetAllDocumentsRequest) String() string {
 func (*GetAllDocumentsRequest) ProtoMessage() {}
 
 func (x *GetAllDocumentsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_documents_proto_msgTypes[1]
+	mi := &file_proto_documents_proto_msgTypes[2]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -157,7 +346,7 @@ func (x *GetAllDocumentsRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetAllDocumentsRequest.ProtoReflect.Descriptor instead.
 func (*GetAllDocumentsRequest) Descriptor() ([]byte, []int) {
-	return file_proto_documents_proto_rawDescGZIP(), []int{1}
+	return file_proto_documents_proto_rawDescGZIP(), []int{2}
 }
 
 // Response con lista de documentos
@@ -171,7 +360,7 @@ type GetAllDocumentsResponse struct {
 
 func (x *GetAllDocumentsResponse) Reset() {
 	*x = GetAllDocumentsResponse{}
-	mi := &file_proto_documents_proto_msgTypes[2]
+	mi := &file_proto_documents_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -183,7 +372,7 @@ func (x *GetAllDocumentsResponse) String() string {
 func (*GetAllDocumentsResponse) ProtoMessage() {}
 
 func (x *GetAllDocumentsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_documents_proto_msgTypes[2]
+	mi := &file_proto_documents_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -196,7 +385,7 @@ func (x *GetAllDocumentsResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetAllDocumentsResponse.ProtoReflect.Descriptor instead.
 func (*GetAllDocumentsResponse) Descriptor() ([]byte, []int) {
-	return file_proto_documents_proto_rawDescGZIP(), []int{2}
+	return file_proto_documents_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *GetAllDocumentsResponse) GetDocuments() []*Document {
@@ -213,6 +402,158 @@ func (x *GetAllDocumentsResponse) GetTotal() int32 {
 	return 0
 }
 
+// Request para listar documentos paginados y filtrados
+type ListDocumentsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Número máximo de documentos por página; el servidor aplica un valor por defecto si es 0
+	PageSize int32 `protobuf:"varint,1,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	// next_page_token de la respuesta anterior; vacío para la primera página
+	PageToken string `protobuf:"bytes,2,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	// Si no está vacío, solo documentos de esta categoría
+	Category string `protobuf:"bytes,3,opt,name=category,proto3" json:"category,omitempty"`
+	// Si no está vacío, solo documentos que tengan todas estas etiquetas
+	Tags          []string      `protobuf:"bytes,4,rep,name=tags,proto3" json:"tags,omitempty"`
+	OrderBy       DocumentOrder `protobuf:"varint,5,opt,name=order_by,json=orderBy,proto3,enum=documents.DocumentOrder" json:"order_by,omitempty"`
+	View          DocumentView  `protobuf:"varint,6,opt,name=view,proto3,enum=documents.DocumentView" json:"view,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListDocumentsRequest) Reset() {
+	*x = ListDocumentsRequest{}
+	mi := &file_proto_documents_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListDocumentsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListDocumentsRequest) ProtoMessage() {}
+
+func (x *ListDocumentsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_documents_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListDocumentsRequest.ProtoReflect.Descriptor instead.
+func (*ListDocumentsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_documents_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ListDocumentsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListDocumentsRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+func (x *ListDocumentsRequest) GetCategory() string {
+	if x != nil {
+		return x.Category
+	}
+	return ""
+}
+
+func (x *ListDocumentsRequest) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *ListDocumentsRequest) GetOrderBy() DocumentOrder {
+	if x != nil {
+		return x.OrderBy
+	}
+	return DocumentOrder_DOCUMENT_ORDER_UNSPECIFIED
+}
+
+func (x *ListDocumentsRequest) GetView() DocumentView {
+	if x != nil {
+		return x.View
+	}
+	return DocumentView_DOCUMENT_VIEW_UNSPECIFIED
+}
+
+// Response con una página de documentos
+type ListDocumentsResponse struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Documents []*Document            `protobuf:"bytes,1,rep,name=documents,proto3" json:"documents,omitempty"`
+	// Token para pedir la siguiente página; vacío si no hay más
+	NextPageToken string `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	// Número total de documentos que cumplen los filtros
+	TotalSize     int32 `protobuf:"varint,3,opt,name=total_size,json=totalSize,proto3" json:"total_size,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListDocumentsResponse) Reset() {
+	*x = ListDocumentsResponse{}
+	mi := &file_proto_documents_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListDocumentsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListDocumentsResponse) ProtoMessage() {}
+
+func (x *ListDocumentsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_documents_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListDocumentsResponse.ProtoReflect.Descriptor instead.
+func (*ListDocumentsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_documents_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ListDocumentsResponse) GetDocuments() []*Document {
+	if x != nil {
+		return x.Documents
+	}
+	return nil
+}
+
+func (x *ListDocumentsResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+func (x *ListDocumentsResponse) GetTotalSize() int32 {
+	if x != nil {
+		return x.TotalSize
+	}
+	return 0
+}
+
 // Request para obtener un documento por ID
 type GetDocumentByIdRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -223,7 +564,7 @@ type GetDocumentByIdRequest struct {
 
 func (x *GetDocumentByIdRequest) Reset() {
 	*x = GetDocumentByIdRequest{}
-	mi := &file_proto_documents_proto_msgTypes[3]
+	mi := &file_proto_documents_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -235,7 +576,7 @@ func (x *GetDocumentByIdRequest) String() string {
 func (*GetDocumentByIdRequest) ProtoMessage() {}
 
 func (x *GetDocumentByIdRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_documents_proto_msgTypes[3]
+	mi := &file_proto_documents_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -248,7 +589,7 @@ func (x *GetDocumentByIdRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetDocumentByIdRequest.ProtoReflect.Descriptor instead.
 func (*GetDocumentByIdRequest) Descriptor() ([]byte, []int) {
-	return file_proto_documents_proto_rawDescGZIP(), []int{3}
+	return file_proto_documents_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *GetDocumentByIdRequest) GetId() string {
@@ -268,7 +609,7 @@ type GetDocumentByIdResponse struct {
 
 func (x *GetDocumentByIdResponse) Reset() {
 	*x = GetDocumentByIdResponse{}
-	mi := &file_proto_documents_proto_msgTypes[4]
+	mi := &file_proto_documents_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -280,7 +621,7 @@ func (x *GetDocumentByIdResponse) String() string {
 func (*GetDocumentByIdResponse) ProtoMessage() {}
 
 func (x *GetDocumentByIdResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_documents_proto_msgTypes[4]
+	mi := &file_proto_documents_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -293,7 +634,7 @@ func (x *GetDocumentByIdResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetDocumentByIdResponse.ProtoReflect.Descriptor instead.
 func (*GetDocumentByIdResponse) Descriptor() ([]byte, []int) {
-	return file_proto_documents_proto_rawDescGZIP(), []int{4}
+	return file_proto_documents_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *GetDocumentByIdResponse) GetDocument() *Document {
@@ -307,7 +648,7 @@ var File_proto_documents_proto protoreflect.FileDescriptor
 
 const file_proto_documents_proto_rawDesc = "" +
 	"\n" +
-	"\x15proto/documents.proto\x12\tdocuments\x1a\x1fgoogle/protobuf/timestamp.proto\"\xff\x01\n" +
+	"\x15proto/documents.proto\x12\tdocuments\x1a\x1fgoogle/protobuf/timestamp.proto\"\xaf\x02\n" +
 	"\bDocument\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x14\n" +
 	"\x05title\x18\x02 \x01(\tR\x05title\x12 \n" +
@@ -319,18 +660,47 @@ const file_proto_documents_proto_rawDesc = "" +
 	"\vcontent_b64\x18\a \x01(\tR\n" +
 	"contentB64\x12\x1f\n" +
 	"\vcover_image\x18\b \x01(\tR\n" +
-	"coverImage\"\x18\n" +
+	"coverImage\x12.\n" +
+	"\x05stats\x18\t \x01(\v2\x18.documents.DocumentStatsR\x05stats\"\x8b\x01\n" +
+	"\rDocumentStats\x12\x14\n" +
+	"\x05words\x18\x01 \x01(\x05R\x05words\x12'\n" +
+	"\x0freading_minutes\x18\x02 \x01(\x05R\x0ereadingMinutes\x12\x1f\n" +
+	"\vcode_blocks\x18\x03 \x01(\x05R\n" +
+	"codeBlocks\x12\x1a\n" +
+	"\bsections\x18\x04 \x01(\x05R\bsections\"\x18\n" +
 	"\x16GetAllDocumentsRequest\"b\n" +
 	"\x17GetAllDocumentsResponse\x121\n" +
 	"\tdocuments\x18\x01 \x03(\v2\x13.documents.DocumentR\tdocuments\x12\x14\n" +
-	"\x05total\x18\x02 \x01(\x05R\x05total\"(\n" +
+	"\x05total\x18\x02 \x01(\x05R\x05total\"\xe4\x01\n" +
+	"\x14ListDocumentsRequest\x12\x1b\n" +
+	"\tpage_size\x18\x01 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x02 \x01(\tR\tpageToken\x12\x1a\n" +
+	"\bcategory\x18\x03 \x01(\tR\bcategory\x12\x12\n" +
+	"\x04tags\x18\x04 \x03(\tR\x04tags\x123\n" +
+	"\border_by\x18\x05 \x01(\x0e2\x18.documents.DocumentOrderR\aorderBy\x12+\n" +
+	"\x04view\x18\x06 \x01(\x0e2\x17.documents.DocumentViewR\x04view\"\x91\x01\n" +
+	"\x15ListDocumentsResponse\x121\n" +
+	"\tdocuments\x18\x01 \x03(\v2\x13.documents.DocumentR\tdocuments\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\x12\x1d\n" +
+	"\n" +
+	"total_size\x18\x03 \x01(\x05R\ttotalSize\"(\n" +
 	"\x16GetDocumentByIdRequest\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\"J\n" +
 	"\x17GetDocumentByIdResponse\x12/\n" +
-	"\bdocument\x18\x01 \x01(\v2\x13.documents.DocumentR\bdocument2\xc5\x01\n" +
+	"\bdocument\x18\x01 \x01(\v2\x13.documents.DocumentR\bdocument*`\n" +
+	"\fDocumentView\x12\x1d\n" +
+	"\x19DOCUMENT_VIEW_UNSPECIFIED\x10\x00\x12\x19\n" +
+	"\x15DOCUMENT_VIEW_SUMMARY\x10\x01\x12\x16\n" +
+	"\x12DOCUMENT_VIEW_FULL\x10\x02*n\n" +
+	"\rDocumentOrder\x12\x1e\n" +
+	"\x1aDOCUMENT_ORDER_UNSPECIFIED\x10\x00\x12\x1f\n" +
+	"\x1bDOCUMENT_ORDER_UPDATED_DESC\x10\x01\x12\x1c\n" +
+	"\x18DOCUMENT_ORDER_TITLE_ASC\x10\x022\x99\x02\n" +
 	"\x0fDocumentService\x12X\n" +
 	"\x0fGetAllDocuments\x12!.documents.GetAllDocumentsRequest\x1a\".documents.GetAllDocumentsResponse\x12X\n" +
-	"\x0fGetDocumentById\x12!.documents.GetDocumentByIdRequest\x1a\".documents.GetDocumentByIdResponseB\x1fZ\x1dmarkitos-it-app-website/protob\x06proto3"
+	"\x0fGetDocumentById\x12!.documents.GetDocumentByIdRequest\x1a\".documents.GetDocumentByIdResponse\x12R\n" +
+	"\rListDocuments\x12\x1f.documents.ListDocumentsRequest\x1a .documents.ListDocumentsResponseB\x1fZ\x1dmarkitos-it-app-website/protob\x06proto3"
 
 var (
 	file_proto_documents_proto_rawDescOnce sync.Once
@@ -344,28 +714,40 @@ func file_proto_documents_proto_rawDescGZIP() []byte {
 	return file_proto_documents_proto_rawDescData
 }
 
-var file_proto_documents_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
+var file_proto_documents_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_proto_documents_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
 var file_proto_documents_proto_goTypes = []any{
-	(*Document)(nil),                // 0: documents.Document
-	(*GetAllDocumentsRequest)(nil),  // 1: documents.GetAllDocumentsRequest
-	(*GetAllDocumentsResponse)(nil), // 2: documents.GetAllDocumentsResponse
-	(*GetDocumentByIdRequest)(nil),  // 3: documents.GetDocumentByIdRequest
-	(*GetDocumentByIdResponse)(nil), // 4: documents.GetDocumentByIdResponse
-	(*timestamppb.Timestamp)(nil),   // 5: google.protobuf.Timestamp
+	(DocumentView)(0),               // 0: documents.DocumentView
+	(DocumentOrder)(0),              // 1: documents.DocumentOrder
+	(*Document)(nil),                // 2: documents.Document
+	(*DocumentStats)(nil),           // 3: documents.DocumentStats
+	(*GetAllDocumentsRequest)(nil),  // 4: documents.GetAllDocumentsRequest
+	(*GetAllDocumentsResponse)(nil), // 5: documents.GetAllDocumentsResponse
+	(*ListDocumentsRequest)(nil),    // 6: documents.ListDocumentsRequest
+	(*ListDocumentsResponse)(nil),   // 7: documents.ListDocumentsResponse
+	(*GetDocumentByIdRequest)(nil),  // 8: documents.GetDocumentByIdRequest
+	(*GetDocumentByIdResponse)(nil), // 9: documents.GetDocumentByIdResponse
+	(*timestamppb.Timestamp)(nil),   // 10: google.protobuf.Timestamp
 }
 var file_proto_documents_proto_depIdxs = []int32{
-	5, // 0: documents.Document.updated_at:type_name -> google.protobuf.Timestamp
-	0, // 1: documents.GetAllDocumentsResponse.documents:type_name -> documents.Document
-	0, // 2: documents.GetDocumentByIdResponse.document:type_name -> documents.Document
-	1, // 3: documents.DocumentService.GetAllDocuments:input_type -> documents.GetAllDocumentsRequest
-	3, // 4: documents.DocumentService.GetDocumentById:input_type -> documents.GetDocumentByIdRequest
-	2, // 5: documents.DocumentService.GetAllDocuments:output_type -> documents.GetAllDocumentsResponse
-	4, // 6: documents.DocumentService.GetDocumentById:output_type -> documents.GetDocumentByIdResponse
-	5, // [5:7] is the sub-list for method output_type
-	3, // [3:5] is the sub-list for method input_type
-	3, // [3:3] is the sub-list for extension type_name
-	3, // [3:3] is the sub-list for extension extendee
-	0, // [0:3] is the sub-list for field type_name
+	10, // 0: documents.Document.updated_at:type_name -> google.protobuf.Timestamp
+	3,  // 1: documents.Document.stats:type_name -> documents.DocumentStats
+	2,  // 2: documents.GetAllDocumentsResponse.documents:type_name -> documents.Document
+	1,  // 3: documents.ListDocumentsRequest.order_by:type_name -> documents.DocumentOrder
+	0,  // 4: documents.ListDocumentsRequest.view:type_name -> documents.DocumentView
+	2,  // 5: documents.ListDocumentsResponse.documents:type_name -> documents.Document
+	2,  // 6: documents.GetDocumentByIdResponse.document:type_name -> documents.Document
+	4,  // 7: documents.DocumentService.GetAllDocuments:input_type -> documents.GetAllDocumentsRequest
+	8,  // 8: documents.DocumentService.GetDocumentById:input_type -> documents.GetDocumentByIdRequest
+	6,  // 9: documents.DocumentService.ListDocuments:input_type -> documents.ListDocumentsRequest
+	5,  // 10: documents.DocumentService.GetAllDocuments:output_type -> documents.GetAllDocumentsResponse
+	9,  // 11: documents.DocumentService.GetDocumentById:output_type -> documents.GetDocumentByIdResponse
+	7,  // 12: documents.DocumentService.ListDocuments:output_type -> documents.ListDocumentsResponse
+	10, // [10:13] is the sub-list for method output_type
+	7,  // [7:10] is the sub-list for method input_type
+	7,  // [7:7] is the sub-list for extension type_name
+	7,  // [7:7] is the sub-list for extension extendee
+	0,  // [0:7] is the sub-list for field type_name
 }
 
 func init() { file_proto_documents_proto_init() }
@@ -378,13 +760,14 @@ func file_proto_documents_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_documents_proto_rawDesc), len(file_proto_documents_proto_rawDesc)),
-			NumEnums:      0,
-			NumMessages:   5,
+			NumEnums:      2,
+			NumMessages:   8,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
 		GoTypes:           file_proto_documents_proto_goTypes,
 		DependencyIndexes: file_proto_documents_proto_depIdxs,
+		EnumInfos:         file_proto_documents_proto_enumTypes,
 		MessageInfos:      file_proto_documents_proto_msgTypes,
 	}.Build()
 	File_proto_documents_proto = out.File
@@ -21,6 +21,7 @@ const _ = grpc.SupportPackageIsVersion9
 const (
 	DocumentService_GetAllDocuments_FullMethodName = "/documents.DocumentService/GetAllDocuments"
 	DocumentService_GetDocumentById_FullMethodName = "/documents.DocumentService/GetDocumentById"
+	DocumentService_ListDocuments_FullMethodName   = "/documents.DocumentService/ListDocuments"
 )
 
 // DocumentServiceClient is the client API for DocumentService service.
@@ -31,6 +32,10 @@ const (
 type DocumentServiceClient interface {
 	GetAllDocuments(ctx context.Context, in *GetAllDocumentsRequest, opts ...grpc.CallOption) (*GetAllDocumentsResponse, error)
 	GetDocumentById(ctx context.Context, in *GetDocumentByIdRequest, opts ...grpc.CallOption) (*GetDocumentByIdResponse, error)
+	// Lista documentos paginados, filtrados por category y tags. Los servidores
+	// que aún no filtran pueden ignorar esos campos: el cliente vuelve a aplicar
+	// los filtros sobre la respuesta.
+	ListDocuments(ctx context.Context, in *ListDocumentsRequest, opts ...grpc.CallOption) (*ListDocumentsResponse, error)
 }
 
 type documentServiceClient struct {
@@ -61,6 +66,16 @@ func (c *documentServiceClient) GetDocumentById(ctx context.Context, in *GetDocu
 	return out, nil
 }
 
+func (c *documentServiceClient) ListDocuments(ctx context.Context, in *ListDocumentsRequest, opts ...grpc.CallOption) (*ListDocumentsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListDocumentsResponse)
+	err := c.cc.Invoke(ctx, DocumentService_ListDocuments_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // DocumentServiceServer is the server API for DocumentService service.
 // All implementations must embed UnimplementedDocumentServiceServer
 // for forward compatibility.
@@ -69,6 +84,10 @@ func (c *documentServiceClient) GetDocumentById(ctx context.Context, in *GetDocu
 type DocumentServiceServer interface {
 	GetAllDocuments(context.Context, *GetAllDocumentsRequest) (*GetAllDocumentsResponse, error)
 	GetDocumentById(context.Context, *GetDocumentByIdRequest) (*GetDocumentByIdResponse, error)
+	// Lista documentos paginados, filtrados por category y tags. Los servidores
+	// que aún no filtran pueden ignorar esos campos: el cliente vuelve a aplicar
+	// los filtros sobre la respuesta.
+	ListDocuments(context.Context, *ListDocumentsRequest) (*ListDocumentsResponse, error)
 	mustEmbedUnimplementedDocumentServiceServer()
 }
 
@@ -85,6 +104,9 @@ func (UnimplementedDocumentServiceServer) GetAllDocuments(context.Context, *GetA
 func (UnimplementedDocumentServiceServer) GetDocumentById(context.Context, *GetDocumentByIdRequest) (*GetDocumentByIdResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method GetDocumentById not implemented")
 }
+func (UnimplementedDocumentServiceServer) ListDocuments(context.Context, *ListDocumentsRequest) (*ListDocumentsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListDocuments not implemented")
+}
 func (UnimplementedDocumentServiceServer) mustEmbedUnimplementedDocumentServiceServer() {}
 func (UnimplementedDocumentServiceServer) testEmbeddedByValue()                         {}
 
@@ -142,6 +164,24 @@ func _DocumentService_GetDocumentById_Handler(srv interface{}, ctx context.Conte
 	return interceptor(ctx, in, info, handler)
 }
 
+func _DocumentService_ListDocuments_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListDocumentsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DocumentServiceServer).ListDocuments(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DocumentService_ListDocuments_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DocumentServiceServer).ListDocuments(ctx, req.(*ListDocumentsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // DocumentService_ServiceDesc is the grpc.ServiceDesc for DocumentService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -157,6 +197,10 @@ var DocumentService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetDocumentById",
 			Handler:    _DocumentService_GetDocumentById_Handler,
 		},
+		{
+			MethodName: "ListDocuments",
+			Handler:    _DocumentService_ListDocuments_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "proto/documents.proto",